@@ -0,0 +1,111 @@
+package blackbody
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+// Physical constants in SI units (CODATA 2018).
+const (
+	Planck           = 6.62607015e-34 // J s
+	SpeedOfLight     = 299792458      // m/s
+	Boltzmann        = 1.380649e-23   // J/K
+	StefanBoltzmann  = 5.670374419e-8 // W/(m² K⁴)
+	WienDisplacement = 2.897771955e-3 // m K
+)
+
+// steps is the number of Simpson intervals used for band integration.
+const steps = 1000
+
+var (
+	ErrInvalidWavelength = errors.New("wavelength must be positive")
+	ErrInvalidBand       = errors.New("band upper wavelength must be greater than lower wavelength")
+	ErrZeroTemperature   = errors.New("blackbody does not radiate at absolute zero")
+)
+
+// SpectralRadiance returns the Planck spectral radiance in W/(m² sr m) at a wavelength in metres
+// of a blackbody at the temperature of t.
+func SpectralRadiance(t *scale.Scale, wavelength float64) (float64, error) {
+	if wavelength <= 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrInvalidWavelength)
+	}
+
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	return planck(k, wavelength), nil
+}
+
+// PeakWavelength returns the wavelength in metres at which the spectral radiance of a blackbody
+// at the temperature of t peaks, according to Wien's displacement law.
+func PeakWavelength(t *scale.Scale) (float64, error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	return WienDisplacement / k, nil
+}
+
+// Exitance returns the total power emitted per area in W/m² by a blackbody at the temperature of t,
+// according to the Stefan–Boltzmann law.
+func Exitance(t *scale.Scale) (float64, error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	return StefanBoltzmann * math.Pow(k, 4), nil
+}
+
+// BandRadiance returns the radiance in W/(m² sr) of a blackbody at the temperature of t integrated
+// over the wavelengths from lower to upper in metres.
+func BandRadiance(t *scale.Scale, lower, upper float64) (float64, error) {
+	if lower <= 0 || upper <= 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrInvalidWavelength)
+	} else if upper <= lower {
+		return 0, fmt.Errorf("tempconv: %w", ErrInvalidBand)
+	}
+
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	// Composite Simpson's rule
+	h := (upper - lower) / steps
+	sum := planck(k, lower) + planck(k, upper)
+	for i := 1; i < steps; i++ {
+		if i%2 == 0 {
+			sum += 2 * planck(k, lower+float64(i)*h)
+		} else {
+			sum += 4 * planck(k, lower+float64(i)*h)
+		}
+	}
+
+	return sum * h / 3, nil
+}
+
+func planck(k, wavelength float64) float64 {
+	return 2 * Planck * SpeedOfLight * SpeedOfLight / math.Pow(wavelength, 5) /
+		math.Expm1(Planck*SpeedOfLight/(wavelength*Boltzmann*k))
+}
+
+func kelvin(s *scale.Scale) (float64, error) {
+	k := scale.NewKelvin()
+	if err := convert.Convert(s, k); err != nil {
+		return 0, err
+	}
+
+	if k.Temp() == 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrZeroTemperature)
+	}
+
+	return k.Temp(), nil
+}
@@ -0,0 +1,102 @@
+package blackbody
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+// sun returns the effective temperature of the Sun.
+func sun(t *testing.T) *scale.Scale {
+	t.Helper()
+	s := scale.NewKelvin()
+	if err := s.SetTemp(5778); err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	return s
+}
+
+func assertRelative(t *testing.T, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want)/want > tolerance {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestSpectralRadiance(t *testing.T) {
+	got, err := SpectralRadiance(sun(t), 500e-9)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	assertRelative(t, got, 2.637566986661e13, 1e-9)
+}
+
+func TestPeakWavelength(t *testing.T) {
+	got, err := PeakWavelength(sun(t))
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	assertRelative(t, got, 5.015181645898e-7, 1e-9)
+}
+
+func TestExitance(t *testing.T) {
+	s := scale.NewCelsius()
+	err := s.SetTemp(5778 - 273.15)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	got, err := Exitance(s)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	assertRelative(t, got, 6.320069973479e7, 1e-9)
+}
+
+func TestBandRadiance(t *testing.T) {
+	t.Run("visible", func(t *testing.T) {
+		got, err := BandRadiance(sun(t), 400e-9, 700e-9)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+
+		assertRelative(t, got, 7.376237446384e6, 1e-9)
+	})
+
+	t.Run("total", func(t *testing.T) {
+		got, err := BandRadiance(sun(t), 50e-9, 50e-6)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+
+		want, _ := Exitance(sun(t))
+		assertRelative(t, got, want/math.Pi, 1e-3)
+	})
+}
+
+func TestError(t *testing.T) {
+	zero := scale.NewKelvin()
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"zero wavelength", func() error { _, err := SpectralRadiance(sun(t), 0); return err }(), ErrInvalidWavelength},
+		{"negative band", func() error { _, err := BandRadiance(sun(t), -1e-6, 1e-6); return err }(), ErrInvalidWavelength},
+		{"inverted band", func() error { _, err := BandRadiance(sun(t), 2e-6, 1e-6); return err }(), ErrInvalidBand},
+		{"absolute zero", func() error { _, err := PeakWavelength(zero); return err }(), ErrZeroTemperature},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.want) {
+				t.Errorf("got %v want %v", c.err, c.want)
+			}
+		})
+	}
+}
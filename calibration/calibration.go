@@ -0,0 +1,321 @@
+package calibration
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/solbero/tempconv/internal/linalg"
+	"github.com/solbero/tempconv/scale"
+)
+
+const (
+	Linear     = "linear"
+	Polynomial = "polynomial"
+)
+
+var (
+	ErrUnknownMethod  = errors.New("unknown correction method")
+	ErrTooFewPoints   = errors.New("too few calibration points for correction method")
+	ErrDuplicatePoint = errors.New("duplicate measured value in calibration points")
+	ErrSingular       = errors.New("calibration points do not determine polynomial")
+	ErrExtrapolation  = errors.New("reading outside certified calibration range")
+	ErrUnknownFormat  = errors.New("unknown calibration file format")
+	ErrInvalidDegree  = errors.New("polynomial degree must be at least 1")
+	ErrMissingScale   = errors.New("calibration table has no scale")
+	ErrScaleMismatch  = errors.New("temperature not in scale of calibration table")
+)
+
+// Point pairs a reading measured by a sensor with the reference temperature from its calibration certificate.
+type Point struct {
+	Measured  float64 `json:"measured"`
+	Reference float64 `json:"reference"`
+}
+
+// Table is a correction table for a sensor. Readings and references are in Scale, the scale the
+// sensor reads in. Tables returned by New, Load, LoadCSV and LoadJSON must not be modified; a Table
+// built as a literal is validated on every use.
+type Table struct {
+	Sensor string  `json:"sensor"`
+	Scale  string  `json:"scale"`
+	Method string  `json:"method"`
+	Degree int     `json:"degree"`
+	Points []Point `json:"points"`
+	typ    int
+	coef   []float64
+	ready  bool
+}
+
+// New returns a correction table for readings in the scale named scaleName using method, either
+// Linear for piecewise-linear interpolation or Polynomial for a least-squares polynomial of the
+// given degree, which must be at least 1.
+func New(sensor, scaleName, method string, degree int, points []Point) (t *Table, err error) {
+	t = &Table{Sensor: sensor, Scale: scaleName, Method: method, Degree: degree, Points: points}
+	if err = t.init(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Load reads a correction table from a CSV or JSON file, chosen by its extension.
+func Load(path string) (*Table, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".csv" && ext != ".json" {
+		return nil, fmt.Errorf("tempconv: %w: %s", ErrUnknownFormat, path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if ext == ".json" {
+		return LoadJSON(f)
+	}
+
+	return LoadCSV(f, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// LoadCSV reads a piecewise-linear correction table for sensor from CSV with the columns measured and reference.
+// The scale of the table is given by a comment line such as '# scale: celsius'. Other lines starting with '#'
+// are ignored.
+func LoadCSV(r io.Reader, sensor string) (*Table, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var scaleName string
+	for _, line := range strings.Split(string(data), "\n") {
+		comment, ok := strings.CutPrefix(strings.TrimSpace(line), "#")
+		if !ok {
+			continue
+		}
+		if name, ok := strings.CutPrefix(strings.TrimSpace(comment), "scale:"); ok {
+			scaleName = strings.TrimSpace(name)
+		}
+	}
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var points []Point
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "measured") {
+			continue // header
+		}
+
+		measured, err := strconv.ParseFloat(record[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid measured value on record %d: %s", i+1, record[0])
+		}
+
+		reference, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference value on record %d: %s", i+1, record[1])
+		}
+
+		points = append(points, Point{Measured: measured, Reference: reference})
+	}
+
+	return New(sensor, scaleName, Linear, 0, points)
+}
+
+// LoadJSON reads a correction table from JSON, for example
+// {"sensor": "pt100", "scale": "celsius", "method": "polynomial", "degree": 2, "points": [{"measured": 0.1, "reference": 0}, ...]}.
+// The method defaults to linear.
+func LoadJSON(r io.Reader) (*Table, error) {
+	t := &Table{}
+	if err := json.NewDecoder(r).Decode(t); err != nil {
+		return nil, err
+	}
+
+	if err := t.init(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Correct returns the reading raw corrected according to the table.
+// It returns an error if raw lies outside the range of measured values in the table.
+func (t *Table) Correct(raw float64) (float64, error) {
+	v, _, err := t.correct(raw)
+	return v, err
+}
+
+// Apply corrects the temperature of s according to the table, and scales its standard uncertainty
+// by the slope of the correction. It returns an error if s is not in the scale of the table or
+// its temperature lies outside the range of measured values in the table. s is left unchanged
+// on error.
+func (t *Table) Apply(s *scale.Scale) error {
+	if !t.ready {
+		c := *t
+		if err := c.init(); err != nil {
+			return err
+		}
+		return c.Apply(s)
+	}
+
+	if s == nil || s.Type != t.typ {
+		return fmt.Errorf("tempconv: %w: table is in %s", ErrScaleMismatch, t.Scale)
+	}
+
+	v, slope, err := t.correct(s.Temp())
+	if err != nil {
+		return err
+	}
+
+	u := math.Abs(slope) * s.Uncertainty()
+	corrected := *s
+	if err := corrected.SetTemp(v); err != nil {
+		return err
+	}
+	if err := corrected.SetUncertainty(u); err != nil {
+		return err
+	}
+
+	*s = corrected
+	return nil
+}
+
+// correct returns the reading raw corrected according to the table and the slope of the
+// correction at raw.
+func (t *Table) correct(raw float64) (v, slope float64, err error) {
+	if !t.ready {
+		c := *t
+		if err := c.init(); err != nil {
+			return 0, 0, err
+		}
+		return c.correct(raw)
+	}
+
+	first, last := t.Points[0], t.Points[len(t.Points)-1]
+	if raw < first.Measured || raw > last.Measured {
+		return 0, 0, fmt.Errorf("tempconv: %w", ErrExtrapolation)
+	}
+
+	if t.Method == Polynomial {
+		for i := len(t.coef) - 1; i >= 0; i-- {
+			slope = slope*raw + v
+			v = v*raw + t.coef[i]
+		}
+		return v, slope, nil
+	}
+
+	i := sort.Search(len(t.Points), func(i int) bool { return t.Points[i].Measured >= raw })
+	if i == 0 {
+		i = 1
+	}
+
+	a, b := t.Points[i-1], t.Points[i]
+	slope = (b.Reference - a.Reference) / (b.Measured - a.Measured)
+	return a.Reference + (raw-a.Measured)*slope, slope, nil
+}
+
+// init validates the table, resolves its scale, sorts its points and fits the polynomial of
+// polynomial tables. The method defaults to linear.
+func (t *Table) init() (err error) {
+	if t.Method == "" {
+		t.Method = Linear
+	}
+
+	var min int
+	switch t.Method {
+	case Linear:
+		min = 2
+	case Polynomial:
+		if t.Degree < 1 {
+			return fmt.Errorf("tempconv: %w: %d", ErrInvalidDegree, t.Degree)
+		}
+		min = t.Degree + 1
+	default:
+		return fmt.Errorf("tempconv: %w: %s", ErrUnknownMethod, t.Method)
+	}
+
+	if len(t.Points) < min {
+		return fmt.Errorf("tempconv: %w", ErrTooFewPoints)
+	}
+
+	if t.Scale == "" {
+		return fmt.Errorf("tempconv: %w", ErrMissingScale)
+	}
+
+	s, err := scale.Lookup(t.Scale)
+	if err != nil {
+		return fmt.Errorf("tempconv: %w", err)
+	}
+	t.Scale, t.typ = s.Name, s.Type
+
+	points := make([]Point, len(t.Points))
+	copy(points, t.Points)
+	sort.Slice(points, func(i, j int) bool { return points[i].Measured < points[j].Measured })
+
+	for i := 1; i < len(points); i++ {
+		if points[i].Measured == points[i-1].Measured {
+			return fmt.Errorf("tempconv: %w", ErrDuplicatePoint)
+		}
+	}
+	t.Points = points
+
+	if t.Method == Polynomial {
+		t.coef, err = fit(t.Points, t.Degree)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.ready = true
+	return nil
+}
+
+// fit returns the coefficients, lowest order first, of the least-squares polynomial of degree through points.
+func fit(points []Point, degree int) ([]float64, error) {
+	n := degree + 1
+
+	// Normal equations as an augmented matrix
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n+1)
+	}
+
+	for _, p := range points {
+		pow := make([]float64, 2*n)
+		pow[0] = 1
+		for i := 1; i < len(pow); i++ {
+			pow[i] = pow[i-1] * p.Measured
+		}
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i][j] += pow[i+j]
+			}
+			a[i][n] += pow[i] * p.Reference
+		}
+	}
+
+	coef, ok := linalg.Solve(a)
+	if !ok {
+		return nil, fmt.Errorf("tempconv: %w", ErrSingular)
+	}
+
+	return coef, nil
+}
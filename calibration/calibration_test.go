@@ -0,0 +1,238 @@
+package calibration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+var points = []Point{
+	{Measured: 100.4, Reference: 100},
+	{Measured: -0.2, Reference: 0},
+	{Measured: 50.3, Reference: 50},
+}
+
+func TestLinear(t *testing.T) {
+	table, err := New("probe", "celsius", Linear, 0, points)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	cases := []struct {
+		raw  float64
+		want float64
+	}{
+		{-0.2, 0},
+		{0, 0.19801980198},
+		{25.05, 25},
+		{50.3, 50},
+		{75.35, 75},
+		{100.4, 100},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g", c.raw), func(t *testing.T) {
+			got, err := table.Correct(c.raw)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(got-c.want) > 1e-9 {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPolynomial(t *testing.T) {
+	// Reference is 0.5 + 0.9 x + 0.001 x² of the measured value x
+	var quadratic []Point
+	for _, x := range []float64{0, 20, 40, 60, 80, 100} {
+		quadratic = append(quadratic, Point{Measured: x, Reference: 0.5 + 0.9*x + 0.001*x*x})
+	}
+
+	table, err := New("probe", "celsius", Polynomial, 2, quadratic)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	for _, x := range []float64{0, 12.5, 55, 100} {
+		t.Run(fmt.Sprintf("%g", x), func(t *testing.T) {
+			got, err := table.Correct(x)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			want := 0.5 + 0.9*x + 0.001*x*x
+			if math.Abs(got-want) > 1e-9 {
+				t.Errorf("got %v want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	table, err := New("probe", "celsius", Linear, 0, points)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	s := scale.NewCelsius()
+	err = s.SetTemp(25.05)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	err = s.SetUncertainty(0.1)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	err = table.Apply(s)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	// The correction between -0.2 and 50.3 has a slope of 50/50.5
+	if math.Abs(s.Temp()-25) > 1e-9 {
+		t.Errorf("got %v want %v", s.Temp(), 25)
+	}
+	if want := 0.1 * 50 / 50.5; math.Abs(s.Uncertainty()-want) > 1e-12 {
+		t.Errorf("got %v want %v", s.Uncertainty(), want)
+	}
+}
+
+func TestApplyPolynomial(t *testing.T) {
+	// Reference is 0.9 x + 0.001 x² of the measured value x, with a slope of 0.9 + 0.002 x
+	var quadratic []Point
+	for _, x := range []float64{0, 50, 100} {
+		quadratic = append(quadratic, Point{Measured: x, Reference: 0.9*x + 0.001*x*x})
+	}
+
+	table, err := New("probe", "fahrenheit", Polynomial, 2, quadratic)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	s := scale.NewFahrenheit()
+	err = s.SetTemp(50)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	err = s.SetUncertainty(1)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	err = table.Apply(s)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	if math.Abs(s.Temp()-47.5) > 1e-9 {
+		t.Errorf("got %v want %v", s.Temp(), 47.5)
+	}
+	if math.Abs(s.Uncertainty()-1) > 1e-9 {
+		t.Errorf("got %v want %v", s.Uncertainty(), 1)
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	table := &Table{Scale: "celsius", Points: []Point{{Measured: 100, Reference: 100}, {Measured: 0, Reference: 0}}}
+
+	got, err := table.Correct(50)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if got != 50 {
+		t.Errorf("got %v want %v", got, 50)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"probe.csv":  "# certificate 1234\n# scale: c\nmeasured,reference\n-0.2,0\n50.3,50\n100.4,100\n",
+		"probe.json": `{"sensor": "probe", "scale": "°C", "points": [{"measured": -0.2, "reference": 0}, {"measured": 50.3, "reference": 50}, {"measured": 100.4, "reference": 100}]}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			table, err := Load(path)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if table.Sensor != "probe" || table.Scale != "celsius" || table.Method != Linear || len(table.Points) != 3 {
+				t.Errorf("got %+v", table)
+			}
+
+			got, _ := table.Correct(50.3)
+			if got != 50 {
+				t.Errorf("got %v want %v", got, 50)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	table, _ := New("probe", "celsius", Linear, 0, points)
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"below range", func() error { _, err := table.Correct(-1); return err }(), ErrExtrapolation},
+		{"above range", func() error { _, err := table.Correct(101); return err }(), ErrExtrapolation},
+		{"unknown method", func() error { _, err := New("probe", "celsius", "spline", 0, points); return err }(), ErrUnknownMethod},
+		{"too few linear", func() error { _, err := New("probe", "celsius", Linear, 0, points[:1]); return err }(), ErrTooFewPoints},
+		{"too few polynomial", func() error { _, err := New("probe", "celsius", Polynomial, 3, points); return err }(), ErrTooFewPoints},
+		{"duplicate point", func() error {
+			_, err := New("probe", "celsius", Linear, 0, append([]Point{{Measured: -0.2, Reference: 1}}, points...))
+			return err
+		}(), ErrDuplicatePoint},
+		{"unknown format", func() error { _, err := Load("probe.xml"); return err }(), ErrUnknownFormat},
+		{"missing file", func() error { _, err := Load("missing.csv"); return err }(), os.ErrNotExist},
+		{"empty table", func() error { _, err := (&Table{}).Correct(0); return err }(), ErrTooFewPoints},
+		{"literal without scale", func() error {
+			_, err := (&Table{Method: Polynomial, Degree: 1, Points: points}).Correct(50)
+			return err
+		}(), ErrMissingScale},
+		{"zero degree", func() error { _, err := New("probe", "celsius", Polynomial, 0, points); return err }(), ErrInvalidDegree},
+		{"json without degree", func() error {
+			_, err := LoadJSON(strings.NewReader(`{"scale": "celsius", "method": "polynomial", "points": [{"measured": 0, "reference": 0}]}`))
+			return err
+		}(), ErrInvalidDegree},
+		{"missing scale", func() error { _, err := New("probe", "", Linear, 0, points); return err }(), ErrMissingScale},
+		{"csv without scale", func() error { _, err := LoadCSV(strings.NewReader("0,0\n1,1\n"), "probe"); return err }(), ErrMissingScale},
+		{"unknown scale", func() error { _, err := New("probe", "foo", Linear, 0, points); return err }(), scale.ErrUnknownScale},
+		{"apply other scale", table.Apply(scale.NewFahrenheit()), ErrScaleMismatch},
+		{"apply outside range", func() error {
+			s := scale.NewCelsius()
+			s.SetTemp(200)
+			return table.Apply(s)
+		}(), ErrExtrapolation},
+		{"json method", func() error {
+			_, err := LoadJSON(strings.NewReader(`{"method": "spline", "points": []}`))
+			return err
+		}(), ErrUnknownMethod},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.want) {
+				t.Errorf("got %v want %v", c.err, c.want)
+			}
+		})
+	}
+}
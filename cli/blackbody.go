@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/solbero/tempconv/blackbody"
+	"github.com/solbero/tempconv/scale"
+)
+
+var blackbodyTemplateParsed *template.Template
+
+const blackbodyHelpTemplate = `tempconv blackbody computes the radiation of a blackbody at a temperature.
+
+Usage:
+  tempconv blackbody [-csv -d <int> -wl <nm> -band <nm,nm>] temp from_scale
+  tempconv blackbody [-csv -d <int>] -spectrum <nm,nm,nm> temp from_scale
+
+Prints the peak wavelength and the emitted power per area, and optionally the spectral
+radiance at a wavelength and the radiance integrated over a band. With -spectrum the
+spectral radiance is tabulated from, to and in steps of the given wavelengths instead, in at
+most 10000 rows.
+
+Arguments:
+  temp        Temperature of the blackbody
+  from_scale  Scale of the temperature
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-8s" .Name}} {{.Usage}}
+{{- end}}
+
+Examples:
+  tempconv blackbody 5778 kelvin
+  tempconv blackbody -wl 500 -band 400,700 5778 k
+  tempconv blackbody -csv -spectrum 100,3000,10 5778 k`
+
+// maxSpectrumRows is the largest number of wavelengths tabulated with -spectrum.
+const maxSpectrumRows = 10000
+
+type blackbodyConfig struct {
+	temp       float64
+	input      *scale.Scale
+	wavelength float64
+	band       []float64
+	spectrum   []float64
+	csv        bool
+	decimal    int
+	help       bool
+}
+
+func init() {
+	blackbodyTemplateParsed = template.Must(template.New("blackbody").Parse(blackbodyHelpTemplate))
+}
+
+func ParseBlackbodyArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *blackbodyConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	var band, spectrum string
+	conf = &blackbodyConfig{}
+	flags.Float64Var(&conf.wavelength, "wl", 0, "Wavelength in nm to compute the spectral radiance at")
+	flags.StringVar(&band, "band", "", "Wavelength band in nm to integrate the radiance over")
+	flags.StringVar(&spectrum, "spectrum", "", "Tabulate the spectral radiance from,to,step in nm")
+	flags.BoolVar(&conf.csv, "csv", false, "Output as CSV")
+	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(separateNegatives(args, flags))
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	_, err = applySettings(flags)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	if conf.help {
+		return conf, nil
+	}
+
+	err = checkDecimal(conf.decimal)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Check flag values
+	var msg string
+	if spectrum != "" && (band != "" || isFlagSet(flags, "wl")) {
+		msg = "mutually exclusive flags: -spectrum, -wl, -band"
+	} else if isFlagSet(flags, "wl") && conf.wavelength <= 0 {
+		msg = fmt.Sprintf("invalid value for -wl flag: %g", conf.wavelength)
+	}
+
+	if band != "" && msg == "" {
+		conf.band, err = parseFloats(band, 2)
+		if err != nil {
+			msg = fmt.Sprintf("invalid value for -band flag: %s", band)
+		}
+	}
+
+	if spectrum != "" && msg == "" {
+		conf.spectrum, err = parseFloats(spectrum, 3)
+		if err != nil || conf.spectrum[0] <= 0 || conf.spectrum[1] < conf.spectrum[0] || conf.spectrum[2] <= 0 {
+			msg = fmt.Sprintf("invalid value for -spectrum flag: %s", spectrum)
+		} else if rows := (conf.spectrum[1]-conf.spectrum[0])/conf.spectrum[2] + 1; !(rows <= maxSpectrumRows) {
+			msg = fmt.Sprintf("invalid value for -spectrum flag: %s, more than %d rows", spectrum, maxSpectrumRows)
+		}
+	}
+
+	if msg != "" {
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	// Check non-flag arguments
+	nonFlagArgs := flags.Args()
+	err = checkArgs(nonFlagArgs, []string{"temp", "from scale"})
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Parse non-flag arguments
+	conf.temp, err = strconv.ParseFloat(nonFlagArgs[0], 64)
+	if err != nil {
+		msg = fmt.Sprintf("invalid value for temp argument: %s", nonFlagArgs[0])
+		fprinte(w, msg)
+		return nil, numberError(msg)
+	}
+
+	conf.input, err = parseScale(nonFlagArgs[1])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func RunBlackbody(w io.Writer, conf *blackbodyConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(scale.ScaleNames(), flags)
+		blackbodyTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	err = conf.input.SetTemp(conf.temp)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	var rows [][]string
+	if conf.spectrum != nil {
+		rows, err = blackbodySpectrum(conf)
+	} else {
+		rows, err = blackbodyQuantities(conf)
+	}
+
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	if conf.csv {
+		b := new(strings.Builder)
+		cw := csv.NewWriter(b)
+		cw.WriteAll(rows)
+		fmt.Fprint(w, strings.TrimSuffix(b.String(), "\n"))
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, row := range rows {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprint(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func blackbodyQuantities(conf *blackbodyConfig) (rows [][]string, err error) {
+	rows = [][]string{{"quantity", "value", "unit"}}
+	e := func(v float64) string { return strconv.FormatFloat(v, 'e', conf.decimal, 64) }
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', conf.decimal, 64) }
+
+	peak, err := blackbody.PeakWavelength(conf.input)
+	if err != nil {
+		return nil, err
+	}
+	rows = append(rows, []string{"peak wavelength", f(peak * 1e9), "nm"})
+
+	exitance, err := blackbody.Exitance(conf.input)
+	if err != nil {
+		return nil, err
+	}
+	rows = append(rows, []string{"exitance", e(exitance), "W/m²"})
+
+	if conf.wavelength != 0 {
+		radiance, err := blackbody.SpectralRadiance(conf.input, conf.wavelength*1e-9)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []string{"spectral radiance", e(radiance), "W/(m² sr m)"})
+	}
+
+	if conf.band != nil {
+		radiance, err := blackbody.BandRadiance(conf.input, conf.band[0]*1e-9, conf.band[1]*1e-9)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []string{"band radiance", e(radiance), "W/(m² sr)"})
+	}
+
+	return rows, nil
+}
+
+func blackbodySpectrum(conf *blackbodyConfig) (rows [][]string, err error) {
+	rows = [][]string{{"wavelength (nm)", "spectral radiance (W/(m² sr m))"}}
+	from, to, step := conf.spectrum[0], conf.spectrum[1], conf.spectrum[2]
+
+	for i := 0; from+float64(i)*step <= to; i++ {
+		wavelength := from + float64(i)*step
+		radiance, err := blackbody.SpectralRadiance(conf.input, wavelength*1e-9)
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, []string{
+			strconv.FormatFloat(wavelength, 'f', conf.decimal, 64),
+			strconv.FormatFloat(radiance, 'e', conf.decimal, 64),
+		})
+	}
+
+	return rows, nil
+}
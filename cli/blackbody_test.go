@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestBlackbody(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"5778", "kelvin"},
+			"quantity         value     unit\npeak wavelength  501.52    nm\nexitance         6.32e+07  W/m²"},
+		{[]string{"-csv", "-wl", "500", "-band", "400,700", "5778", "k"},
+			"quantity,value,unit\npeak wavelength,501.52,nm\nexitance,6.32e+07,W/m²\n" +
+				"spectral radiance,2.64e+13,W/(m² sr m)\nband radiance,7.38e+06,W/(m² sr)"},
+		{[]string{"-csv", "-d", "3", "-spectrum", "400,600,100", "5778", "k"},
+			"wavelength (nm),spectral radiance (W/(m² sr m))\n400.000,2.306e+13\n500.000,2.638e+13\n600.000,2.453e+13"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseBlackbodyArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunBlackbody(w, conf, flags)
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+			if w.String() != c.want {
+				t.Errorf("got %q want %q", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestBlackbodyHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseBlackbodyArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunBlackbody(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestBlackbodyError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{}},
+		{[]string{"5778"}},
+		{[]string{"5778", "k", "extra"}},
+		{[]string{"hot", "k"}},
+		{[]string{"5778", "wedgwood"}},
+		{[]string{"-wl", "0", "5778", "k"}},
+		{[]string{"-band", "400", "5778", "k"}},
+		{[]string{"-spectrum", "400,700", "5778", "k"}},
+		{[]string{"-spectrum", "700,400,10", "5778", "k"}},
+		{[]string{"-spectrum", "400,700,0", "5778", "k"}},
+		{[]string{"-spectrum", "1,1e12,1e-9", "5778", "k"}},
+		{[]string{"-spectrum", "1,inf,1", "5778", "k"}},
+		{[]string{"-spectrum", "400,700,10", "-wl", "500", "5778", "k"}},
+		{[]string{"-d", "13", "5778", "k"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseBlackbodyArgs(w, c.args, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestBlackbodyRunError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{"0", "k"}},
+		{[]string{"--", "-300", "c"}},
+		{[]string{"-band", "700,400", "5778", "k"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseBlackbodyArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunBlackbody(w, conf, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/template"
+
+	"github.com/solbero/tempconv/color"
+	"github.com/solbero/tempconv/scale"
+)
+
+var colorTemplateParsed *template.Template
+
+const colorHelpTemplate = `tempconv color converts between color temperatures, colors, mireds and chromaticities.
+
+Usage:
+  tempconv color [-f hex|rgb|mired -d <int>] temp from_scale
+  tempconv color [-u -d <int>] -mired <float> to_scale
+  tempconv color [-u -d <int> -m mccamy|hernandez] -xy <x,y> to_scale
+
+Arguments:
+  temp        Color temperature to convert
+  from_scale  Scale to convert color temperature from
+  to_scale    Scale to return the color temperature in
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-5s" .Name}} {{.Usage}}
+{{- end}}
+
+Examples:
+  tempconv color 2700 kelvin
+  tempconv color -f mired 6500 k
+  tempconv color -xy 0.3127,0.3290 k`
+
+type colorConfig struct {
+	temp    float64
+	input   *scale.Scale
+	output  *scale.Scale
+	format  string
+	mired   float64
+	xy      []float64
+	method  string
+	decimal int
+	unit    bool
+	help    bool
+}
+
+func init() {
+	colorTemplateParsed = template.Must(template.New("color").Parse(colorHelpTemplate))
+}
+
+func ParseColorArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *colorConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	var xy string
+	conf = &colorConfig{}
+	flags.StringVar(&conf.format, "f", "hex", "Output format for temperatures: hex, rgb or mired")
+	flags.Float64Var(&conf.mired, "mired", 0, "Convert mireds to a color temperature")
+	flags.StringVar(&xy, "xy", "", "Compute correlated color temperature from CIE 1931 chromaticity x,y")
+	flags.StringVar(&conf.method, "m", "mccamy", "Correlated color temperature method: mccamy or hernandez")
+	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
+	flags.BoolVar(&conf.unit, "u", false, "Include temperature unit")
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(separateNegatives(args, flags))
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	_, err = applySettings(flags)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	if conf.help {
+		return conf, nil
+	}
+
+	err = checkDecimal(conf.decimal)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Check flag values
+	var msg string
+	if isFlagSet(flags, "mired") && xy != "" {
+		msg = "mutually exclusive flags: -mired, -xy"
+	} else if conf.format != "hex" && conf.format != "rgb" && conf.format != "mired" {
+		msg = fmt.Sprintf("invalid value for -f flag: %s", conf.format)
+	} else if conf.method != "mccamy" && conf.method != "hernandez" {
+		msg = fmt.Sprintf("invalid value for -m flag: %s", conf.method)
+	}
+
+	if msg != "" {
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	if xy != "" {
+		conf.xy, err = parseFloats(xy, 2)
+		if err != nil {
+			msg = fmt.Sprintf("invalid value for -xy flag: %s", xy)
+			fprinte(w, msg)
+			return nil, errors.New(msg)
+		}
+	}
+
+	// Check non-flag arguments
+	nonFlagArgs := flags.Args()
+	if conf.xy != nil || isFlagSet(flags, "mired") {
+		err = checkArgs(nonFlagArgs, []string{"to scale"})
+		if err != nil {
+			fprinte(w, err.Error())
+			return nil, err
+		}
+
+		conf.output, err = parseScale(nonFlagArgs[0])
+		if err != nil {
+			fprinte(w, err.Error())
+			return nil, err
+		}
+
+		return conf, nil
+	}
+
+	err = checkArgs(nonFlagArgs, []string{"temp", "from scale"})
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Parse non-flag arguments
+	conf.temp, err = strconv.ParseFloat(nonFlagArgs[0], 64)
+	if err != nil {
+		msg = fmt.Sprintf("invalid value for temp argument: %s", nonFlagArgs[0])
+		fprinte(w, msg)
+		return nil, numberError(msg)
+	}
+
+	conf.input, err = parseScale(nonFlagArgs[1])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func RunColor(w io.Writer, conf *colorConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(scale.ScaleNames(), flags)
+		colorTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	if conf.output != nil {
+		if conf.xy == nil {
+			err = color.FromMired(conf.mired, conf.output)
+		} else if conf.method == "hernandez" {
+			err = color.HernandezAndres(conf.xy[0], conf.xy[1], conf.output)
+		} else {
+			err = color.McCamy(conf.xy[0], conf.xy[1], conf.output)
+		}
+
+		if err != nil {
+			fprinte(w, errorMessage(err))
+			return err
+		}
+
+		if conf.unit {
+			fmt.Fprintf(w, "%.*f %s", conf.decimal, conf.output.Temp(), conf.output.Unit)
+		} else {
+			fmt.Fprintf(w, "%.*f", conf.decimal, conf.output.Temp())
+		}
+		return nil
+	}
+
+	err = conf.input.SetTemp(conf.temp)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	if conf.format == "mired" {
+		m, err := color.ToMired(conf.input)
+		if err != nil {
+			fprinte(w, errorMessage(err))
+			return err
+		}
+
+		fmt.Fprintf(w, "%.*f", conf.decimal, m)
+		return nil
+	}
+
+	c, err := color.ToRGB(conf.input)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	if conf.format == "rgb" {
+		fmt.Fprint(w, c)
+	} else {
+		fmt.Fprint(w, c.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"2700", "kelvin"}, "#ffa757"},
+		{[]string{"-f", "rgb", "2700", "k"}, "255,167,87"},
+		{[]string{"-f", "mired", "-d", "0", "4000", "k"}, "250"},
+		{[]string{"-mired", "250", "k"}, "4000.00"},
+		{[]string{"-mired", "250", "-u", "c"}, "3726.85 °C"},
+		{[]string{"-xy", "0.31271,0.32902", "-d", "0", "k"}, "6504"},
+		{[]string{"-xy", "0.31271,0.32902", "-m", "hernandez", "-d", "0", "k"}, "6500"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseColorArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunColor(w, conf, flags)
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+			if w.String() != c.want {
+				t.Errorf("got %v want %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestColorHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseColorArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunColor(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestColorError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{}},
+		{[]string{"2700"}},
+		{[]string{"2700", "k", "extra"}},
+		{[]string{"warm", "k"}},
+		{[]string{"2700", "wedgwood"}},
+		{[]string{"-f", "cmyk", "2700", "k"}},
+		{[]string{"-m", "robertson", "-xy", "0.3,0.3", "k"}},
+		{[]string{"-xy", "0.3", "k"}},
+		{[]string{"-xy", "0.3,0.3"}},
+		{[]string{"-xy", "0.3,0.3", "-mired", "250", "k"}},
+		{[]string{"-d", "13", "2700", "k"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseColorArgs(w, c.args, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestColorRunError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{"500", "k"}},
+		{[]string{"--", "-300", "c"}},
+		{[]string{"-mired", "-1", "k"}},
+		{[]string{"-xy", "1.2,0.3", "k"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseColorArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunColor(w, conf, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// command is a tempconv subcommand. Its run function parses args with a flag set of its own.
+// The kinds of its positional arguments are used for shell completion.
+type command struct {
+	name       string
+	summary    string
+	run        func(w io.Writer, args []string, flags *flag.FlagSet, version string) error
+	positional []string
+	hidden     bool
+}
+
+// commands are the subcommands of tempconv, initialized in init to break the reference cycle through help.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"convert", "Convert a temperature between scales [default]", convertCommand,
+			[]string{"", argScale, argScale}, false},
+		{"scales", "List the supported temperature scales", scalesCommand, nil, false},
+		{"table", "Show a temperature in every scale", tableCommand,
+			[]string{"", argScale}, false},
+		{"thermistor", "Convert between thermistor resistance and temperature", thermistorCommand,
+			[]string{"", argScale}, false},
+		{"weather", "Compute heat index, wind chill, dew point or humidex", weatherCommand,
+			[]string{argIndex, "", argScale, argScale}, false},
+		{"color", "Convert color temperatures", colorCommand,
+			[]string{"", argScale}, false},
+		{"blackbody", "Compute blackbody radiation", blackbodyCommand,
+			[]string{"", argScale}, false},
+		{"config", "Show the effective settings and their sources", configCommand, nil, false},
+		{"completion", "Print a shell completion script", completionCommand,
+			[]string{argShell}, false},
+		{"version", "Show version and exit", versionCommand, nil, false},
+		{"help", "Show help for a command", helpCommand,
+			[]string{argCommand}, false},
+		{"__complete", "Print completion candidates", completeCommand, nil, true},
+	}
+}
+
+// Execute runs the command named by the first argument with the remaining arguments.
+// If the first argument is not a command, all arguments are passed to convert, so that
+// 'tempconv 0 c k' keeps working as 'tempconv convert 0 c k'.
+func Execute(w io.Writer, args []string, version string) error {
+	name := "convert"
+	if len(args) > 0 {
+		if _, ok := lookupCommand(args[0]); ok {
+			name, args = args[0], args[1:]
+		}
+	}
+
+	cmd, _ := lookupCommand(name)
+	flags := flag.NewFlagSet("tempconv "+cmd.name, flag.ContinueOnError)
+	if cmd.name == "convert" {
+		flags = flag.NewFlagSet("tempconv", flag.ContinueOnError)
+	}
+
+	return cmd.run(w, args, flags, version)
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+
+	return command{}, false
+}
+
+func convertCommand(w io.Writer, args []string, flags *flag.FlagSet, version string) error {
+	conf, err := ParseArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return Run(w, conf, flags, version)
+}
+
+func thermistorCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseThermistorArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunThermistor(w, conf, flags)
+}
+
+func weatherCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseWeatherArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunWeather(w, conf, flags)
+}
+
+func colorCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseColorArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunColor(w, conf, flags)
+}
+
+func blackbodyCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseBlackbodyArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunBlackbody(w, conf, flags)
+}
+
+func configCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseConfigArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunConfig(w, conf, flags)
+}
+
+func scalesCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseScalesArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunScales(w, conf, flags)
+}
+
+func tableCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	conf, err := ParseTableArgs(w, args, flags)
+	if err != nil {
+		return err
+	}
+
+	return RunTable(w, conf, flags)
+}
+
+var (
+	versionTemplateParsed *template.Template
+	helpTemplateParsed    *template.Template
+)
+
+const versionHelpTemplate = `tempconv version shows the version of tempconv.
+
+Usage:
+  tempconv version [-h]
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}`
+
+const helpHelpTemplate = `tempconv help shows the help of a command.
+
+Usage:
+  tempconv help [-h] [command]
+
+Arguments:
+  command  Command to show the help of [default: convert]
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}`
+
+func init() {
+	versionTemplateParsed = template.Must(template.New("version").Parse(versionHelpTemplate))
+	helpTemplateParsed = template.Must(template.New("help").Parse(helpHelpTemplate))
+}
+
+func versionCommand(w io.Writer, args []string, flags *flag.FlagSet, version string) error {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	var help bool
+	flags.BoolVar(&help, "h", false, "Show help and exit")
+
+	err := flags.Parse(args)
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return err
+	}
+
+	if help {
+		versionTemplateParsed.Execute(w, templateData(nil, flags))
+		return nil
+	}
+
+	err = checkArgs(flags.Args(), []string{})
+	if err != nil {
+		fprinte(w, err.Error())
+		return err
+	}
+
+	fmt.Fprint(w, version)
+	return nil
+}
+
+// helpCommand shows the help of the command given as argument by running it with -h.
+func helpCommand(w io.Writer, args []string, flags *flag.FlagSet, version string) error {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	var help bool
+	flags.BoolVar(&help, "h", false, "Show help and exit")
+
+	err := flags.Parse(args)
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return err
+	}
+
+	if help {
+		helpTemplateParsed.Execute(w, templateData(nil, flags))
+		return nil
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		err := checkArgs(args, []string{"command"})
+		fprinte(w, err.Error())
+		return err
+	}
+
+	name := "convert"
+	if len(args) == 1 {
+		name = args[0]
+	}
+
+	cmd, ok := lookupCommand(name)
+	if !ok || cmd.hidden {
+		msg := fmt.Sprintf("unknown command: %s", name)
+		fprinte(w, msg)
+		return errors.New(msg)
+	}
+
+	return Execute(w, []string{cmd.name, "-h"}, version)
+}
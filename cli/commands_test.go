@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"0", "celsius", "kelvin"}, "273.15"},
+		{[]string{"-u", "0", "c", "k"}, "273.15 K"},
+		{[]string{"convert", "0", "c", "k"}, "273.15"},
+		{[]string{"convert", "-u", "0", "c", "k"}, "273.15 K"},
+		{[]string{"-u", "-10", "c", "k"}, "263.15 K"},
+		{[]string{"-u", "32", "°F", "K"}, "273.15 K"},
+		{[]string{"-below-zero", "allow", "-300", "c", "k"}, "-26.85"},
+		{[]string{"-below-zero", "clamp", "-300", "c", "k"}, "0.00"},
+		{[]string{"-nonfinite", "allow", "nan", "c", "k"}, "NaN"},
+		{[]string{"-u", "100", "℃", "degF"}, "212.00 °F"},
+		{[]string{"-v"}, "test"},
+		{[]string{"version"}, "test"},
+		{[]string{"thermistor", "-beta", "10000,25,3950", "10000", "c"}, "25.00"},
+		{[]string{"weather", "-rh", "50", "dewpoint", "20", "c", "c"}, "9.26"},
+		{[]string{"color", "2700", "k"}, "#ffa757"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			err := Execute(w, c.args, "test")
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if w.String() != c.want {
+				t.Errorf("got %v want %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestExecuteHelp(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-h"}, "tempconv converts temperatures"},
+		{[]string{"help"}, "tempconv converts temperatures"},
+		{[]string{"convert", "-h"}, "tempconv converts temperatures"},
+		{[]string{"help", "convert"}, "tempconv converts temperatures"},
+		{[]string{"help", "scales"}, "tempconv scales lists"},
+		{[]string{"help", "table"}, "tempconv table shows"},
+		{[]string{"table", "-h"}, "tempconv table shows"},
+		{[]string{"help", "config"}, "tempconv config shows"},
+		{[]string{"help", "version"}, "tempconv version shows"},
+		{[]string{"version", "-h"}, "tempconv version shows"},
+		{[]string{"help", "help"}, "tempconv help shows"},
+		{[]string{"help", "-h"}, "tempconv help shows"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			err := Execute(w, c.args, "test")
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if !strings.HasPrefix(w.String(), c.want) {
+				t.Errorf("got %v want prefix %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestExecuteCommandsListed(t *testing.T) {
+	w := new(bytes.Buffer)
+	err := Execute(w, []string{"-h"}, "test")
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	for _, cmd := range commands {
+		listed := strings.Contains(w.String(), "  "+cmd.name+" ")
+		if !cmd.hidden && !listed {
+			t.Errorf("help does not list command %v", cmd.name)
+		} else if cmd.hidden && listed {
+			t.Errorf("help lists hidden command %v", cmd.name)
+		}
+	}
+}
+
+func TestExecuteError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{}},
+		{[]string{"convert"}},
+		{[]string{"nope", "c", "k"}},
+		{[]string{"version", "extra"}},
+		{[]string{"version", "-x"}},
+		{[]string{"help", "nope"}},
+		{[]string{"help", "-x"}},
+		{[]string{"help", "convert", "extra"}},
+		{[]string{"help", "__complete"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			err := Execute(w, c.args, "test")
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+// Kinds of positional arguments which can be completed.
+const (
+	argScale   = "scale"
+	argIndex   = "index"
+	argCommand = "command"
+	argShell   = "shell"
+)
+
+var completionTemplateParsed *template.Template
+
+const completionHelpTemplate = `tempconv completion prints a shell completion script.
+
+Usage:
+  tempconv completion shell
+
+Arguments:
+  shell  Shell to print the completion script for: bash, zsh, fish or powershell
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}
+
+Examples:
+  source <(tempconv completion bash)
+  source <(tempconv completion zsh)
+  tempconv completion fish | source
+  tempconv completion powershell | Out-String | Invoke-Expression`
+
+// The completion scripts pass the words up to and including the one being completed
+// to the hidden __complete command, which prints the candidates one per line.
+//
+// Bash splits COMP_WORDS at the characters in COMP_WORDBREAKS, such as = and :, so the bash
+// script splits the line at whitespace itself, and removes the part of the current word up to
+// the last = or : from the candidates, as bash only replaces the part after it.
+var completionScripts = map[string]string{
+	"bash": `_tempconv() {
+    local IFS=$' \t\n' line="${COMP_LINE:0:COMP_POINT}" cur prefix breaks
+    local -a words
+    read -ra words <<< "$line"
+    if [[ -z $line || $line == *[[:space:]] ]]; then
+        words+=("")
+    fi
+    cur="${words[${#words[@]}-1]}"
+
+    IFS=$'\n'
+    COMPREPLY=($(tempconv __complete "${words[@]:1}" 2>/dev/null))
+
+    breaks="${COMP_WORDBREAKS//[^=:]/}"
+    if [[ -n $breaks && $cur == *["$breaks"]* ]]; then
+        prefix="${cur%"${cur##*["$breaks"]}"}"
+        COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+    fi
+}
+complete -o default -F _tempconv tempconv`,
+
+	"zsh": `#compdef tempconv
+_tempconv() {
+    local -a candidates
+    candidates=(${(f)"$(tempconv __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    compadd -a candidates
+}
+compdef _tempconv tempconv`,
+
+	"fish": `function __tempconv_complete
+    set -l args (commandline -opc)[2..-1] (commandline -ct)
+    tempconv __complete $args 2>/dev/null
+end
+complete -c tempconv -f -a '(__tempconv_complete)'`,
+
+	"powershell": `Register-ArgumentCompleter -Native -CommandName tempconv -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -eq '') { $words += '' }
+    tempconv __complete @words 2>$null | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}`,
+}
+
+func init() {
+	completionTemplateParsed = template.Must(template.New("completion").Parse(completionHelpTemplate))
+}
+
+func completionCommand(w io.Writer, args []string, flags *flag.FlagSet, _ string) error {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	var help bool
+	flags.BoolVar(&help, "h", false, "Show help and exit")
+
+	err := flags.Parse(args)
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return err
+	}
+
+	if help {
+		data := templateData(nil, flags)
+		completionTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	err = checkArgs(flags.Args(), []string{"shell"})
+	if err != nil {
+		fprinte(w, err.Error())
+		return err
+	}
+
+	script, ok := completionScripts[flags.Arg(0)]
+	if !ok {
+		msg := fmt.Sprintf("unknown shell: %s, must be one of: %s", flags.Arg(0), strings.Join(shells(), ", "))
+		fprinte(w, msg)
+		return errors.New(msg)
+	}
+
+	fmt.Fprint(w, script)
+	return nil
+}
+
+// completeCommand prints the completion candidates for the last of args, given the words before it.
+// It never fails, as errors would only end up in the terminal of the user.
+func completeCommand(w io.Writer, args []string, _ *flag.FlagSet, version string) error {
+	fmt.Fprint(w, strings.Join(complete(args, version), "\n"))
+	return nil
+}
+
+func complete(args []string, version string) []string {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	current, prior := args[len(args)-1], args[:len(args)-1]
+
+	// Find the command, which is convert unless named by the first word
+	cmd, _ := lookupCommand("convert")
+	if len(prior) > 0 {
+		if c, ok := lookupCommand(prior[0]); ok {
+			cmd, prior = c, prior[1:]
+		}
+	}
+
+	// Define the flags of the command by showing its help
+	flags := flag.NewFlagSet("tempconv "+cmd.name, flag.ContinueOnError)
+	cmd.run(io.Discard, []string{"-h"}, flags, version)
+
+	// Complete the value of a flag given as -name=value
+	if name, value, ok := strings.Cut(current, "="); ok && strings.HasPrefix(name, "-") {
+		var candidates []string
+		if f := flags.Lookup(strings.TrimLeft(name, "-")); f != nil && !isBoolFlag(f) {
+			for _, v := range completeFlagValue(f.Name, value) {
+				candidates = append(candidates, name+"="+v)
+			}
+		}
+		return filterPrefix(candidates, current)
+	}
+
+	if strings.HasPrefix(current, "-") {
+		var candidates []string
+		flags.VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, "-"+f.Name)
+		})
+		return filterPrefix(candidates, current)
+	}
+
+	// Count the positional arguments before the current word, skipping flags and their values
+	position := 0
+	for i := 0; i < len(prior); i++ {
+		if prior[i] == "--" || !strings.HasPrefix(prior[i], "-") || isNegativeNumber(prior[i]) {
+			position++
+			continue
+		}
+
+		name := strings.TrimLeft(prior[i], "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+
+		if f := flags.Lookup(name); f != nil && !isBoolFlag(f) {
+			if i == len(prior)-1 {
+				return completeFlagValue(name, current)
+			}
+			i++ // skip flag value
+		}
+	}
+
+	// Commands are completed alongside the arguments of the implicit convert command
+	var candidates []string
+	if len(args) == 1 {
+		candidates = append(candidates, commandNames()...)
+	}
+
+	if position < len(cmd.positional) {
+		switch cmd.positional[position] {
+		case argScale:
+			candidates = append(candidates, flatten(scale.ScaleNames())...)
+		case argIndex:
+			candidates = append(candidates, weatherIndices...)
+		case argCommand:
+			candidates = append(candidates, commandNames()...)
+		case argShell:
+			candidates = append(candidates, shells()...)
+		}
+	}
+
+	return filterPrefix(candidates, current)
+}
+
+func completeFlagValue(name, current string) []string {
+	if name == "format" {
+		return filterPrefix([]string{"value", "short", "long", "equation"}, current)
+	}
+
+	return []string{}
+}
+
+func commandNames() (names []string) {
+	for _, cmd := range commands {
+		if !cmd.hidden {
+			names = append(names, cmd.name)
+		}
+	}
+	return names
+}
+
+func shells() (names []string) {
+	for name := range completionScripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func filterPrefix(candidates []string, prefix string) []string {
+	filtered := []string{}
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
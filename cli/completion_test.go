@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompletion(t *testing.T) {
+	var cases = []struct {
+		shell string
+		want  string
+	}{
+		{"bash", "complete -o default -F _tempconv tempconv"},
+		{"zsh", "compdef _tempconv tempconv"},
+		{"fish", "complete -c tempconv"},
+		{"powershell", "Register-ArgumentCompleter -Native -CommandName tempconv"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.shell, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("tempconv completion", flag.ContinueOnError)
+			err := completionCommand(w, []string{c.shell}, flags, "test")
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if !strings.Contains(w.String(), c.want) {
+				t.Errorf("got %v want to contain %v", w.String(), c.want)
+			}
+
+			if !strings.Contains(w.String(), "tempconv __complete") {
+				t.Errorf("got %v want to contain %v", w.String(), "tempconv __complete")
+			}
+		})
+	}
+}
+
+func TestCompletionHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("tempconv completion", flag.ContinueOnError)
+	err := completionCommand(w, []string{"-h"}, flags, "test")
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	want := "tempconv completion prints"
+	if !strings.HasPrefix(w.String(), want) {
+		t.Errorf("got %v want prefix %v", w.String(), want)
+	}
+}
+
+func TestCompletionError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{}},
+		{[]string{"tcsh"}},
+		{[]string{"bash", "zsh"}},
+		{[]string{"-x", "bash"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("tempconv completion", flag.ContinueOnError)
+			err := completionCommand(w, c.args, flags, "test")
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"t"}, []string{"table", "thermistor"}},
+		{[]string{"co"}, []string{"convert", "color", "config", "completion"}},
+		{[]string{"-"}, []string{"-below-zero", "-calibration", "-d", "-format", "-from-code", "-h", "-max", "-nonfinite", "-sig", "-to-code", "-u", "-v"}},
+		{[]string{"-s"}, []string{"-sig"}},
+		{[]string{"-from-code", "ucum:Cel", "0", "k"}, []string{"kelvin"}},
+		{[]string{"0", "c"}, []string{"celsius"}},
+		{[]string{"0", "celsius", "r"}, []string{"rankine", "réaumur", "reaumur", "rømer", "romer"}},
+		{[]string{"-d", "2", "0", "k"}, []string{"kelvin"}},
+		{[]string{"-u", "0", "k"}, []string{"kelvin"}},
+		{[]string{"-10", ""}, []string{"kelvin", "celsius", "fahrenheit", "rankine", "delisle", "newton", "réaumur", "reaumur", "rømer", "romer"}},
+		{[]string{"-u", "-10", "c", ""}, []string{"kelvin", "celsius", "fahrenheit", "rankine", "delisle", "newton", "réaumur", "reaumur", "rømer", "romer"}},
+		{[]string{"-10", "c", "k", ""}, []string{}},
+		{[]string{"table", "-.5", "r"}, []string{"rankine", "réaumur", "reaumur", "rømer", "romer"}},
+		{[]string{"0", "c", "k", ""}, []string{}},
+		{[]string{"-format", "e"}, []string{"equation"}},
+		{[]string{"-format=e"}, []string{"-format=equation"}},
+		{[]string{"--format="}, []string{"--format=value", "--format=short", "--format=long", "--format=equation"}},
+		{[]string{"-d="}, []string{}},
+		{[]string{"-u=t"}, []string{}},
+		{[]string{"-d", ""}, []string{}},
+		{[]string{"convert", "0", "f"}, []string{"fahrenheit"}},
+		{[]string{"table", "-"}, []string{"-d", "-h", "-u"}},
+		{[]string{"weather", "h"}, []string{"heatindex", "humidex"}},
+		{[]string{"weather", "-rh", "50", "d"}, []string{"dewpoint"}},
+		{[]string{"weather", "dewpoint", "20", "n"}, []string{"newton"}},
+		{[]string{"help", "sc"}, []string{"scales"}},
+		{[]string{"completion", "p"}, []string{"powershell"}},
+		{[]string{"scales", ""}, []string{}},
+		{[]string{"__"}, []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			err := Execute(w, append([]string{"__complete"}, c.args...), "test")
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			got := []string{}
+			if w.Len() > 0 {
+				got = strings.Split(w.String(), "\n")
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestCompletionBash runs the bash completion script on command lines as typed, with the test
+// binary standing in for tempconv.
+func TestCompletionBash(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not found")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scales := []string{"kelvin", "celsius", "fahrenheit", "rankine", "delisle", "newton", "réaumur", "reaumur", "rømer", "romer"}
+	var cases = []struct {
+		line string
+		want []string
+	}{
+		{"tempconv ", commandNames()},
+		{"tempconv -u -10 c ", scales},
+		{"tempconv -from-code ucum:Cel 0 k", []string{"kelvin"}},
+		{"tempconv -d=2 0 c f", []string{"fahrenheit"}},
+		{"tempconv -format=e", []string{"equation"}},
+		{"tempconv -format=", []string{"value", "short", "long", "equation"}},
+		{"tempconv weather -rh 50 d", []string{"dewpoint"}},
+	}
+
+	script := completionScripts["bash"] + `
+tempconv() { TEMPCONV_TEST_MAIN=1 "$TEMPCONV_TEST_EXE" "$@"; }
+COMP_WORDBREAKS=$' \t\n"\'><=;|&(:'
+COMP_LINE="$1"
+COMP_POINT=${#COMP_LINE}
+_tempconv
+printf '%s\n' "${COMPREPLY[@]}"`
+
+	for _, c := range cases {
+		t.Run(c.line, func(t *testing.T) {
+			cmd := exec.Command(bash, "--norc", "--noprofile", "-c", script, "bash", c.line)
+			cmd.Env = append(os.Environ(), "TEMPCONV_TEST_EXE="+exe)
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			got := strings.Fields(string(out))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %q want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+)
+
+// Doc describes a command for generated documentation.
+type Doc struct {
+	Name    string
+	Summary string
+	Usage   []string
+	Flags   []*flag.Flag
+}
+
+// Docs returns the documentation of every command, in the order they are listed in the help.
+// The usage and flags are taken from the commands themselves, so the documentation cannot drift
+// from the behavior of the binary.
+func Docs() (docs []Doc) {
+	for _, cmd := range commands {
+		if cmd.hidden {
+			continue
+		}
+
+		// Define the flags and render the help of the command by running it with -h
+		w := new(bytes.Buffer)
+		flags := flag.NewFlagSet("tempconv "+cmd.name, flag.ContinueOnError)
+		cmd.run(w, []string{"-h"}, flags, "")
+
+		doc := Doc{Name: cmd.name, Summary: cmd.summary, Usage: usageLines(w)}
+		if doc.Usage == nil {
+			line := "tempconv " + cmd.name
+			for _, arg := range cmd.positional {
+				line += " [" + arg + "]"
+			}
+			doc.Usage = []string{line}
+		}
+
+		flags.VisitAll(func(f *flag.Flag) {
+			doc.Flags = append(doc.Flags, f)
+		})
+		docs = append(docs, doc)
+	}
+
+	return docs
+}
+
+// usageLines returns the lines of the Usage section of a help text.
+func usageLines(r io.Reader) (lines []string) {
+	scanner := bufio.NewScanner(r)
+	inUsage := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "Usage:":
+			inUsage = true
+		case inUsage && strings.TrimSpace(line) == "":
+			return lines
+		case inUsage:
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	// The documentation must not depend on the settings of the user
+	t.Setenv("TEMPCONV_DECIMAL", "abc")
+	docs := Docs()
+
+	var names []string
+	for _, doc := range docs {
+		names = append(names, doc.Name)
+	}
+
+	if !reflect.DeepEqual(names, commandNames()) {
+		t.Errorf("got %v want %v", names, commandNames())
+	}
+
+	var cases = []struct {
+		name  string
+		usage string
+		flags []string
+	}{
+		{"convert", "tempconv [convert] [-u | -format <format>]", []string{"below-zero", "calibration", "d", "format", "from-code", "h", "max", "nonfinite", "sig", "to-code", "u", "v"}},
+		{"table", "tempconv table [-u -d <int>] temp from_scale", []string{"d", "h", "u"}},
+		{"scales", "tempconv scales [-h]", []string{"h"}},
+		{"version", "tempconv version [-h]", []string{"h"}},
+		{"help", "tempconv help [-h] [command]", []string{"h"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var doc Doc
+			for _, d := range docs {
+				if d.Name == c.name {
+					doc = d
+				}
+			}
+
+			if len(doc.Usage) == 0 || !strings.HasPrefix(doc.Usage[0], c.usage) {
+				t.Errorf("got %v want prefix %v", doc.Usage, c.usage)
+			}
+
+			var flags []string
+			for _, f := range doc.Flags {
+				flags = append(flags, f.Name)
+			}
+
+			if !reflect.DeepEqual(flags, c.flags) {
+				t.Errorf("got %v want %v", flags, c.flags)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+
+	"github.com/solbero/tempconv/blackbody"
+	"github.com/solbero/tempconv/calibration"
+	"github.com/solbero/tempconv/color"
+	"github.com/solbero/tempconv/format"
+	"github.com/solbero/tempconv/scale"
+	"github.com/solbero/tempconv/thermistor"
+	"github.com/solbero/tempconv/units"
+	"github.com/solbero/tempconv/weather"
+)
+
+// Exit codes of tempconv. Failures which are not of a more specific kind exit with ExitUsage.
+const (
+	ExitOK     = 0 // Success
+	ExitUsage  = 2 // Invalid flags, arguments, settings or output format
+	ExitScale  = 3 // Unknown or ambiguous scale or unit code
+	ExitNumber = 4 // Invalid number
+	ExitRange  = 5 // Physically impossible value, such as a temperature below absolute zero
+	ExitIO     = 6 // Unable to read a file, such as the config or calibration file
+)
+
+// ExitStatus describes an exit code and the kind of failure it represents.
+type ExitStatus struct {
+	Code        int
+	Kind        string
+	Description string
+}
+
+// ExitStatuses describes the exit codes of tempconv, in order.
+var ExitStatuses = []ExitStatus{
+	{ExitOK, "ok", "Success"},
+	{ExitUsage, "usage", "Invalid flags, arguments, settings or output format"},
+	{ExitScale, "scale", "Unknown or ambiguous scale or unit code"},
+	{ExitNumber, "number", "Invalid number"},
+	{ExitRange, "range", "Physically impossible value, such as a temperature below absolute zero"},
+	{ExitIO, "io", "Unable to read a file, such as the config or calibration file"},
+}
+
+// rangeErrors are the library errors for values which are physically impossible or out of range.
+var rangeErrors = []error{
+	scale.ErrAbsoluteZero,
+	scale.ErrNonFinite,
+	scale.ErrNegativeUncertainty,
+	scale.ErrImplausible,
+	calibration.ErrExtrapolation,
+	weather.ErrOutOfRange,
+	weather.ErrInvalidHumidity,
+	weather.ErrInvalidWindSpeed,
+	color.ErrOutOfRange,
+	color.ErrInvalidMired,
+	color.ErrInvalidChromaticity,
+	thermistor.ErrInvalidResistance,
+	thermistor.ErrOutOfRange,
+	blackbody.ErrInvalidWavelength,
+	blackbody.ErrInvalidBand,
+	blackbody.ErrZeroTemperature,
+}
+
+// exitError is an error with the exit code tempconv should exit with.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+func (e exitError) Unwrap() error { return e.err }
+
+// numberError returns an error with msg for arguments which are not valid numbers.
+func numberError(msg string) error {
+	return exitError{code: ExitNumber, err: errors.New(msg)}
+}
+
+// ExitCode returns the exit code for err, which is ExitOK if err is nil.
+func ExitCode(err error) int {
+	var exit exitError
+	var num *strconv.NumError
+	var path *fs.PathError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exit):
+		return exit.code
+	case errors.Is(err, scale.ErrUnknownScale), errors.Is(err, scale.ErrAmbiguousScale),
+		errors.Is(err, units.ErrUnknownCode), errors.Is(err, units.ErrUnknownSystem):
+		return ExitScale
+	case errors.As(err, &num), errors.Is(err, format.ErrInvalidLiteral):
+		return ExitNumber
+	case errors.As(err, &path):
+		return ExitIO
+	}
+
+	for _, target := range rangeErrors {
+		if errors.Is(err, target) {
+			return ExitRange
+		}
+	}
+
+	return ExitUsage
+}
+
+// jsonError is the JSON object written for errors with the json errors setting.
+type jsonError struct {
+	Code    int    `json:"code"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func writeJSONError(w io.Writer, err error) {
+	code := ExitCode(err)
+	var kind string
+	for _, s := range ExitStatuses {
+		if s.Code == code {
+			kind = s.Kind
+		}
+	}
+
+	b, _ := json.Marshal(jsonError{Code: code, Kind: kind, Message: errorMessage(err)})
+	fmt.Fprintln(w, string(b))
+}
+
+// Main runs tempconv with args, writing output to stdout and errors to stderr, and returns the
+// exit code. Errors are written as JSON objects if the errors setting is json.
+func Main(stdout, stderr io.Writer, args []string, version string) int {
+	buff := new(bytes.Buffer)
+
+	err := Execute(buff, args, version)
+	if err != nil {
+		if errorsSetting() == "json" {
+			writeJSONError(stderr, err)
+		} else {
+			fmt.Fprintln(stderr, buff.String())
+		}
+		return ExitCode(err)
+	}
+
+	buff.WriteByte('\n')
+	fmt.Fprint(stdout, buff.String())
+	return ExitOK
+}
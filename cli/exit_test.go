@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want int
+	}{
+		{[]string{"0", "c", "k"}, ExitOK},
+		{[]string{"-x", "0", "c", "k"}, ExitUsage},
+		{[]string{"0", "c"}, ExitUsage},
+		{[]string{"-u", "-format", "short", "0", "c", "k"}, ExitUsage},
+		{[]string{"help", "nope"}, ExitUsage},
+		{[]string{"0", "wedgwood", "k"}, ExitScale},
+		{[]string{"0", "r", "k"}, ExitScale},
+		{[]string{"-from-code", "ucum:X", "0", "k"}, ExitScale},
+		{[]string{"fifty", "c", "k"}, ExitNumber},
+		{[]string{"table", "fifty", "c"}, ExitNumber},
+		{[]string{"thermistor", "-beta", "10000,25,3950", "ohm", "c"}, ExitNumber},
+		{[]string{"-sig", "1e5", "c", "k"}, ExitOK},
+		{[]string{"-300", "c", "k"}, ExitRange},
+		{[]string{"table", "-300", "c"}, ExitRange},
+		{[]string{"20±-0.1", "c", "k"}, ExitRange},
+		{[]string{"table", "20±-0.1", "c"}, ExitRange},
+		{[]string{"20±x", "c", "k"}, ExitNumber},
+		{[]string{"weather", "-rh", "150", "dewpoint", "20", "c", "c"}, ExitRange},
+		{[]string{"color", "100", "k"}, ExitRange},
+		{[]string{"-calibration", filepath.Join("testdata", "missing.csv"), "0", "c", "k"}, ExitIO},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			err := Execute(new(bytes.Buffer), c.args, "test")
+			got := ExitCode(err)
+			if got != c.want {
+				t.Errorf("got %v want %v for %v", got, c.want, err)
+			}
+		})
+	}
+}
+
+func TestExitCodeWrapped(t *testing.T) {
+	err := numberError("invalid value")
+	if got := ExitCode(errors.Join(errors.New("context"), err)); got != ExitNumber {
+		t.Errorf("got %v want %v", got, ExitNumber)
+	}
+}
+
+func TestExitStatuses(t *testing.T) {
+	seen := map[int]bool{}
+	for _, s := range ExitStatuses {
+		if seen[s.Code] {
+			t.Errorf("duplicate exit code %v", s.Code)
+		}
+		seen[s.Code] = true
+	}
+
+	for _, code := range []int{ExitOK, ExitUsage, ExitScale, ExitNumber, ExitRange, ExitIO} {
+		if !seen[code] {
+			t.Errorf("exit code %v is not described", code)
+		}
+	}
+}
+
+func TestMainText(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := Main(stdout, stderr, []string{"0", "c", "k"}, "test")
+	if code != ExitOK {
+		t.Errorf("got %v want %v", code, ExitOK)
+	}
+
+	if stdout.String() != "273.15\n" || stderr.Len() != 0 {
+		t.Errorf("got %q and %q want %q and %q", stdout.String(), stderr.String(), "273.15\n", "")
+	}
+
+	stdout.Reset()
+	code = Main(stdout, stderr, []string{"0", "wedgwood", "k"}, "test")
+	if code != ExitScale {
+		t.Errorf("got %v want %v", code, ExitScale)
+	}
+
+	want := "unknown temperature scale: wedgwood\n" + usageMsg
+	if stdout.Len() != 0 || !strings.HasPrefix(stderr.String(), want) {
+		t.Errorf("got %q and %q want %q and %q", stdout.String(), stderr.String(), "", want)
+	}
+}
+
+func TestMainErrorsSettingInvalid(t *testing.T) {
+	t.Setenv("TEMPCONV_ERRORS", "xml")
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := Main(stdout, stderr, []string{"0", "c", "k"}, "test")
+	if code != ExitUsage {
+		t.Errorf("got %v want %v", code, ExitUsage)
+	}
+
+	want := "invalid value for errors setting from environment TEMPCONV_ERRORS: xml, must be one of: text, json\n"
+	if stdout.Len() != 0 || !strings.HasPrefix(stderr.String(), want) {
+		t.Errorf("got %q and %q want %q and %q", stdout.String(), stderr.String(), "", want)
+	}
+}
+
+func TestMainJSON(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want jsonError
+	}{
+		{[]string{"-x", "0", "c", "k"}, jsonError{ExitUsage, "usage", "flag provided but not defined: -x"}},
+		{[]string{"0", "wedgwood", "k"}, jsonError{ExitScale, "scale", "unknown temperature scale: wedgwood"}},
+		{[]string{"fifty", "c", "k"}, jsonError{ExitNumber, "number", "invalid value for temp argument: fifty"}},
+		{[]string{"-300", "c", "k"}, jsonError{ExitRange, "range",
+			"temperature below absolute zero: -300 °C, absolute zero is -273.15 °C"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			t.Setenv("TEMPCONV_ERRORS", "json")
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			code := Main(stdout, stderr, c.args, "test")
+			if code != c.want.Code {
+				t.Errorf("got %v want %v", code, c.want.Code)
+			}
+
+			var got jsonError
+			err := json.Unmarshal(stderr.Bytes(), &got)
+			if err != nil {
+				t.Fatalf("got %v want %v for %q", err, nil, stderr.String())
+			}
+
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+
+			if stdout.Len() != 0 || strings.Count(stderr.String(), "\n") != 1 {
+				t.Errorf("got %q and %q want a single line on stderr", stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+func TestErrorsSetting(t *testing.T) {
+	var cases = []struct {
+		config string
+		env    string
+		want   string
+	}{
+		{`{}`, "", "text"},
+		{`{"errors": "json"}`, "", "json"},
+		{`{"errors": "json"}`, "text", "text"},
+		{`{}`, "json", "json"},
+		{`{}`, "yaml", "text"},
+		{`not json`, "json", "json"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.config+" "+c.env, func(t *testing.T) {
+			writeConfig(t, c.config)
+			if c.env != "" {
+				t.Setenv("TEMPCONV_ERRORS", c.env)
+			}
+
+			if got := errorsSetting(); got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -5,22 +5,92 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
+	"text/template"
 
+	"github.com/solbero/tempconv/calibration"
+	"github.com/solbero/tempconv/format"
 	"github.com/solbero/tempconv/scale"
+	"github.com/solbero/tempconv/units"
 )
 
 const usageMsg = "try 'tempconv -h' for more information"
 
 type config struct {
-	temp    float64
-	input   *scale.Scale
-	output  *scale.Scale
-	decimal int
-	unit    bool
-	version bool
-	help    bool
+	temp        float64
+	uncertainty float64
+	input       *scale.Scale
+	output      *scale.Scale
+	calibration *calibration.Table
+	format      *template.Template
+	policy      scale.Policy
+	decimal     int
+	sig         int
+	unit        bool
+	version     bool
+	help        bool
+}
+
+// sigFlag is the value of the -sig flag. Given as -sig the significant figures are
+// inferred from the temp argument, given as -sig=<int> the count is explicit.
+type sigFlag struct {
+	set   bool
+	count int
+}
+
+func (s *sigFlag) IsBoolFlag() bool { return true }
+func (s *sigFlag) String() string {
+	if s == nil || !s.set {
+		return "false"
+	} else if s.count == 0 {
+		return "true"
+	}
+	return strconv.Itoa(s.count)
+}
+
+func (s *sigFlag) Set(v string) error {
+	if b, err := strconv.ParseBool(v); err == nil {
+		s.set, s.count = b, 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return errors.New("must be a positive integer")
+	}
+
+	s.set, s.count = true, n
+	return nil
+}
+
+// flagValues holds the values of flags which are parsed further before being stored in config.
+type flagValues struct {
+	calibration string
+	format      string
+	fromCode    string
+	toCode      string
+	nonFinite   string
+	belowZero   string
+	sig         sigFlag
+}
+
+func defineFlags(flags *flag.FlagSet, conf *config) *flagValues {
+	values := &flagValues{}
+	flags.StringVar(&values.calibration, "calibration", "", "Apply sensor calibration from CSV or JSON file to temp")
+	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
+	flags.Var(&values.sig, "sig", "Round to significant figures of temp, or to the number given as -sig=<int>")
+	flags.StringVar(&values.format, "format", "", "Output format preset or template")
+	flags.StringVar(&values.fromCode, "from-code", "", "Scale to convert from as a unit code system:code, instead of from_scale")
+	flags.StringVar(&values.toCode, "to-code", "", "Scale to convert to as a unit code system:code, instead of to_scale")
+	flags.StringVar(&values.nonFinite, "nonfinite", "reject", "Action for NaN and infinite temperatures: reject, allow or clamp")
+	flags.StringVar(&values.belowZero, "below-zero", "error", "Action for temperatures below absolute zero: error, clamp or allow")
+	flags.Float64Var(&conf.policy.Max, "max", 0, "Reject temperatures above this plausibility bound in kelvin [default: none]")
+	flags.BoolVar(&conf.unit, "u", false, "Include temperature unit")
+	flags.BoolVar(&conf.version, "v", false, "Show version and exit")
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+	return values
 }
 
 func ParseArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *config, err error) {
@@ -29,24 +99,34 @@ func ParseArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *config, e
 
 	// Parse flags
 	conf = &config{}
-	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
-	flags.BoolVar(&conf.unit, "u", false, "Include temperature unit")
-	flags.BoolVar(&conf.version, "v", false, "Show version and exit")
-	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+	values := defineFlags(flags, conf)
 
-	err = flags.Parse(args)
+	err = flags.Parse(separateNegatives(args, flags))
 	if err != nil {
 		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
 		return nil, err
 	}
 
+	defaultOutput, err := applySettings(flags)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Explicit flags win over output settings from the config file or environment
+	calibrationFile, outputFormat, sig := values.calibration, values.format, values.sig
+	if isFlagSet(flags, "u") && !isFlagSet(flags, "format") {
+		outputFormat = ""
+	} else if isFlagSet(flags, "format") && !isFlagSet(flags, "u") {
+		conf.unit = false
+	}
+
 	// Check temp decimal places
 	var msg string
-	min, max := 0, 12
-	if conf.decimal < min || conf.decimal > max {
-		msg = fmt.Sprintf("invalid value for -d flag: %v, must be between %v and %v", conf.decimal, min, max)
-		fprinte(w, msg)
-		return nil, fmt.Errorf(msg)
+	err = checkDecimal(conf.decimal)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
 	}
 
 	// Check mutually exclusive flags
@@ -54,6 +134,45 @@ func ParseArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *config, e
 		msg = "mutually exclusive flags: -h, -v"
 		fprinte(w, msg)
 		return nil, errors.New("mutually exclusive flags: -h, -v")
+	} else if sig.set && isFlagSet(flags, "d") {
+		msg = "mutually exclusive flags: -d, -sig"
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	} else if isFlagSet(flags, "u") && isFlagSet(flags, "format") {
+		msg = "mutually exclusive flags: -format, -u"
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	// Parse validation policy
+	conf.policy.NonFinite, err = scale.ParseAction(values.nonFinite)
+	if err != nil {
+		msg = fmt.Sprintf("invalid value for -nonfinite flag: %s", values.nonFinite)
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	conf.policy.AbsoluteZero, err = scale.ParseAction(values.belowZero)
+	if err != nil {
+		msg = fmt.Sprintf("invalid value for -below-zero flag: %s", values.belowZero)
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	if conf.policy.Max < 0 || math.IsNaN(conf.policy.Max) || math.IsInf(conf.policy.Max, 0) {
+		msg = fmt.Sprintf("invalid value for -max flag: %g, must be a finite temperature in kelvin", conf.policy.Max)
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	// Parse output format
+	if outputFormat != "" {
+		conf.format, err = parseFormat(outputFormat)
+		if err != nil {
+			msg = fmt.Sprintf("invalid value for -format flag: %s", err)
+			fprinte(w, msg)
+			return nil, errors.New(msg)
+		}
 	}
 
 	// Print version or help
@@ -61,9 +180,21 @@ func ParseArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *config, e
 		return conf, nil
 	}
 
-	// Check non-flag arguments
+	// Check non-flag arguments, where unit codes take the place of scale arguments
+	required := []string{"temp"}
+	if values.fromCode == "" {
+		required = append(required, "from scale")
+	}
+	if values.toCode == "" {
+		required = append(required, "to scale")
+	}
+
 	nonFlagArgs := flags.Args()
-	err = checkNonFlagArgs(nonFlagArgs)
+	if defaultOutput != "" && values.toCode == "" && len(nonFlagArgs) == len(required)-1 {
+		nonFlagArgs = append(nonFlagArgs, defaultOutput)
+	}
+
+	err = checkArgs(nonFlagArgs, required)
 	if err != nil {
 		fprinte(w, err.Error())
 		return nil, err
@@ -71,78 +202,184 @@ func ParseArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *config, e
 
 	// Parse non-flag arguments
 	temp := nonFlagArgs[0]
-	input := nonFlagArgs[1]
-	output := nonFlagArgs[2]
-	conf.temp, err = strconv.ParseFloat(temp, 64)
+	conf.temp, conf.uncertainty, err = parseTemp(temp)
 
 	if err != nil {
-		msg = fmt.Sprintf("invalid value for temp argument: %s", nonFlagArgs[0])
-		fprinte(w, msg)
-		return nil, fmt.Errorf(msg)
+		return nil, tempArgError(w, temp, err)
+	}
+
+	// Infer significant figures
+	conf.sig = sig.count
+	if sig.set && sig.count == 0 {
+		value, _, _ := splitTemp(temp)
+		conf.sig, err = format.SigFigs(value)
+		if err != nil {
+			msg = fmt.Sprintf("unable to infer significant figures of temp argument: %s", temp)
+			fprinte(w, msg)
+			return nil, errors.New(msg)
+		}
 	}
-	conf.input, err = parseScale(input)
 
+	if values.fromCode != "" {
+		conf.input, err = units.Parse(values.fromCode)
+		if err != nil {
+			err = fmt.Errorf("invalid value for -from-code flag: %w", err)
+			fprinte(w, err.Error())
+			return nil, err
+		}
+	} else {
+		conf.input, err = parseScale(nonFlagArgs[1])
+		if err != nil {
+			fprinte(w, err.Error())
+			return nil, err
+		}
+	}
+
+	if values.toCode != "" {
+		conf.output, err = units.Parse(values.toCode)
+		if err != nil {
+			err = fmt.Errorf("invalid value for -to-code flag: %w", err)
+			fprinte(w, err.Error())
+			return nil, err
+		}
+	} else {
+		conf.output, err = parseScale(nonFlagArgs[len(nonFlagArgs)-1])
+		if err != nil {
+			fprinte(w, err.Error())
+			return nil, err
+		}
+	}
+
+	// Load calibration table
+	if calibrationFile != "" {
+		conf.calibration, err = calibration.Load(calibrationFile)
+		if err != nil {
+			err = fmt.Errorf("invalid value for -calibration flag: %w", err)
+			fprinte(w, err.Error())
+			return nil, err
+		}
+	}
+
+	return conf, nil
+}
+
+// parseTemp parses a temperature optionally followed by a standard uncertainty, as in 20.0±0.1 or 20.0+-0.1.
+func parseTemp(s string) (temp, uncertainty float64, err error) {
+	value, u, found := splitTemp(s)
+
+	temp, err = strconv.ParseFloat(value, 64)
+	if err != nil || !found {
+		return temp, 0, err
+	}
+
+	uncertainty, err = strconv.ParseFloat(u, 64)
+	if err == nil && uncertainty < 0 {
+		err = fmt.Errorf("tempconv: %w: %s", scale.ErrNegativeUncertainty, u)
+	}
+
+	return temp, uncertainty, err
+}
+
+// tempArgError prints and returns the error for the temp argument arg which parseTemp rejected
+// with err. A negative uncertainty is reported with the error of the scale package.
+func tempArgError(w io.Writer, arg string, err error) error {
+	if errors.Is(err, scale.ErrNegativeUncertainty) {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	msg := fmt.Sprintf("invalid value for temp argument: %s", arg)
+	fprinte(w, msg)
+	return numberError(msg)
+}
+
+// parseFormat returns the output format preset called name, or else parses name as a template.
+// The template is executed on empty output data, so fields which do not exist are reported here.
+func parseFormat(name string) (*template.Template, error) {
+	if tmpl, ok := formatPresets[name]; ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := template.New("format").Parse(name)
 	if err != nil {
-		fprinte(w, err.Error())
 		return nil, err
 	}
-	conf.output, err = parseScale(output)
 
-	if err != nil {
-		fprinte(w, err.Error())
+	if err = tmpl.Execute(io.Discard, outputData{}); err != nil {
 		return nil, err
 	}
 
-	return conf, nil
+	return tmpl, nil
+}
+
+// splitTemp splits a temperature literal into its value and standard uncertainty parts.
+func splitTemp(s string) (value, uncertainty string, found bool) {
+	value, uncertainty, found = strings.Cut(strings.Replace(s, "+-", "±", 1), "±")
+	return strings.TrimSpace(value), strings.TrimSpace(uncertainty), found
 }
 
+// parseScale returns the scale identified by name, see scale.Lookup.
 func parseScale(name string) (*scale.Scale, error) {
-	scales := flatten(scale.ScaleNames())
-	matches := matchAll(name, scales)
-
-	if len(matches) == 0 {
-		return nil, fmt.Errorf("unknown temperature scale: %s", name)
-	} else if len(matches) > 1 {
-		return nil, fmt.Errorf("ambiguous temperature scale: %s, matches: %s", name, strings.Join(matches, ", "))
-	}
-
-	switch matches[0] {
-	case "kelvin":
-		return scale.NewKelvin(), nil
-	case "celsius":
-		return scale.NewCelsius(), nil
-	case "fahrenheit":
-		return scale.NewFahrenheit(), nil
-	case "rankine":
-		return scale.NewRankine(), nil
-	case "delisle":
-		return scale.NewDelisle(), nil
-	case "newton":
-		return scale.NewNewton(), nil
-	case "réaumur":
-		return scale.NewReaumur(), nil
-	case "reaumur": // alias
-		return scale.NewReaumur(), nil
-	case "rømer":
-		return scale.NewRomer(), nil
-	case "romer": // alias
-		return scale.NewRomer(), nil
-	default:
-		panic(errors.New("unable to parse scale"))
-	}
-}
-
-func checkNonFlagArgs(args []string) error {
-	required := []string{"temp", "from scale", "to scale"} // required args
-
-	if len(args) == 0 {
-		return fmt.Errorf("missing required arguments: %s", strings.Join(required[0:], ", "))
-	} else if len(args) == 1 {
-		return fmt.Errorf("missing required arguments: %s", strings.Join(required[1:], ", "))
-	} else if len(args) == 2 {
-		return fmt.Errorf("missing required argument: %s", strings.Join(required[2:], ", "))
-	} else if len(args) > 3 {
-		return fmt.Errorf("supplied too many arguments: %v", strings.Join(args[3:], ", "))
+	return scale.Lookup(name)
+}
+
+// separateNegatives returns args with '--' inserted before the first argument that looks like a
+// negative number, so it is parsed as a temperature instead of a flag. Values of flags which take a
+// value, such as '-d -1', are left for the flag to validate.
+func separateNegatives(args []string, flags *flag.FlagSet) []string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			return args
+		}
+
+		if isNegativeNumber(arg) {
+			separated := append([]string{}, args[:i]...)
+			separated = append(separated, "--")
+			return append(separated, args[i:]...)
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+
+		if f := flags.Lookup(name); f != nil && !isBoolFlag(f) {
+			i++ // skip flag value
+		}
+	}
+
+	return args
+}
+
+// isNegativeNumber reports whether s is a minus sign followed by a digit, a decimal point and a
+// digit, or infinity.
+func isNegativeNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	if strings.EqualFold(s, "inf") || strings.EqualFold(s, "infinity") {
+		return true
+	}
+
+	s = strings.TrimPrefix(s, ".")
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+func checkDecimal(decimal int) error {
+	min, max := 0, 12
+	if decimal < min || decimal > max {
+		return fmt.Errorf("invalid value for -d flag: %v, must be between %v and %v", decimal, min, max)
+	}
+
+	return nil
+}
+
+func checkArgs(args []string, required []string) error {
+	if len(args) == len(required)-1 {
+		return fmt.Errorf("missing required argument: %s", required[len(args)])
+	} else if len(args) < len(required) {
+		return fmt.Errorf("missing required arguments: %s", strings.Join(required[len(args):], ", "))
+	} else if len(args) > len(required) {
+		return fmt.Errorf("supplied too many arguments: %v", strings.Join(args[len(required):], ", "))
 	}
 
 	return nil
@@ -174,6 +411,16 @@ func flatten(slice [][]string) []string {
 	return flat
 }
 
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// errorMessage returns the message of err without the tempconv prefix of library errors.
+func errorMessage(err error) string {
+	return strings.TrimPrefix(err.Error(), "tempconv: ")
+}
+
 func fprinte(w io.Writer, msg string) {
 	w.Write([]byte(msg + "\n" + usageMsg))
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -24,8 +26,14 @@ func TestParseArgs(t *testing.T) {
 			&config{temp: 0, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2}},
 		{[]string{"-u", "0", "celsius", "kelvin"},
 			&config{temp: 0, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2, unit: true}},
+		{[]string{"-10", "celsius", "kelvin"},
+			&config{temp: -10, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2}},
 		{[]string{"-u", "-d", "4", "0", "celsius", "kelvin"},
 			&config{temp: 0, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 4, unit: true}},
+		{[]string{"20.0±0.1", "celsius", "fahrenheit"},
+			&config{temp: 20, uncertainty: 0.1, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2}},
+		{[]string{"20.0+-0.1", "celsius", "fahrenheit"},
+			&config{temp: 20, uncertainty: 0.1, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2}},
 		{[]string{"-h"},
 			&config{decimal: 2, help: true}},
 		{[]string{"-h", "0", "celsius", "kelvin"},
@@ -52,6 +60,272 @@ func TestParseArgs(t *testing.T) {
 	}
 }
 
+func TestParseArgsNegative(t *testing.T) {
+	var cases = []struct {
+		args        []string
+		temp        float64
+		uncertainty float64
+		decimal     int
+		unit        bool
+	}{
+		{[]string{"-10", "celsius", "kelvin"}, -10, 0, 2, false},
+		{[]string{"-40", "c", "f"}, -40, 0, 2, false},
+		{[]string{"-0", "c", "f"}, 0, 0, 2, false},
+		{[]string{"-4.5e2", "rankine", "kelvin"}, -450, 0, 2, false},
+		{[]string{"-4.5E+2", "rankine", "kelvin"}, -450, 0, 2, false},
+		{[]string{"-.5", "c", "k"}, -0.5, 0, 2, false},
+		{[]string{"-0.5", "c", "k"}, -0.5, 0, 2, false},
+		{[]string{"-10±0.5", "c", "k"}, -10, 0.5, 2, false},
+		{[]string{"-10+-0.5", "c", "k"}, -10, 0.5, 2, false},
+		{[]string{"-u", "-10", "c", "k"}, -10, 0, 2, true},
+		{[]string{"-d", "4", "-10", "c", "k"}, -10, 0, 4, false},
+		{[]string{"-d=4", "-10", "c", "k"}, -10, 0, 4, false},
+		{[]string{"-u", "-d", "1", "-10", "c", "k"}, -10, 0, 1, true},
+		{[]string{"--", "-10", "c", "k"}, -10, 0, 2, false},
+		{[]string{"-u", "--", "-10", "c", "k"}, -10, 0, 2, true},
+		{[]string{"-format", "short", "-10", "c", "k"}, -10, 0, 2, false},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if conf.temp != c.temp {
+				t.Errorf("got temp %v want %v", conf.temp, c.temp)
+			}
+
+			if conf.uncertainty != c.uncertainty {
+				t.Errorf("got uncertainty %v want %v", conf.uncertainty, c.uncertainty)
+			}
+
+			if conf.decimal != c.decimal {
+				t.Errorf("got decimal %v want %v", conf.decimal, c.decimal)
+			}
+
+			if conf.unit != c.unit {
+				t.Errorf("got unit %v want %v", conf.unit, c.unit)
+			}
+		})
+	}
+}
+
+func TestParseArgsNegativeError(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-x", "0", "c", "k"}, "flag provided but not defined: -x"},
+		{[]string{"-e5", "c", "k"}, "flag provided but not defined: -e5"},
+		{[]string{"-10x", "c", "k"}, "invalid value for temp argument: -10x"},
+		{[]string{"-10", "c"}, "missing required argument"},
+		{[]string{"-d", "-1", "0", "c", "k"}, "invalid value for -d flag: -1"},
+		{[]string{"-u", "-10", "-d", "2", "c", "k"}, "supplied too many arguments"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseArgs(w, c.args, flags)
+			if err == nil {
+				t.Fatalf("got %v want error", err)
+			}
+
+			if !strings.Contains(w.String(), c.want) {
+				t.Errorf("got %v want to contain %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestParseArgsScaleError(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"0", "farenheit", "kelvin"}, "unknown temperature scale: farenheit, did you mean: fahrenheit"},
+		{[]string{"0", "celsius", "kelivn"}, "unknown temperature scale: kelivn, did you mean: kelvin"},
+		{[]string{"0", "r", "kelvin"}, "ambiguous temperature scale: r, matches: rankine, réaumur, rømer"},
+		{[]string{"0", "celsius", "wedgwood"}, "unknown temperature scale: wedgwood\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseArgs(w, c.args, flags)
+			if err == nil {
+				t.Fatalf("got %v want error", err)
+			}
+
+			if !strings.Contains(w.String(), c.want) {
+				t.Errorf("got %v want to contain %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestParseArgsCode(t *testing.T) {
+	var cases = []struct {
+		args   []string
+		input  int
+		output int
+	}{
+		{[]string{"-from-code", "ucum:Cel", "0", "kelvin"}, scale.CELSIUS, scale.KELVIN},
+		{[]string{"-to-code", "unece:FAH", "0", "celsius"}, scale.CELSIUS, scale.FAHRENHEIT},
+		{[]string{"-from-code", "qudt:unit:DEG_C", "-to-code", "bacnet:63", "0"}, scale.CELSIUS, scale.KELVIN},
+		{[]string{"--from-code", "ucum:[degF]", "-10", "c"}, scale.FAHRENHEIT, scale.CELSIUS},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if conf.input.Type != c.input {
+				t.Errorf("got input %v want %v", conf.input.Type, c.input)
+			}
+
+			if conf.output.Type != c.output {
+				t.Errorf("got output %v want %v", conf.output.Type, c.output)
+			}
+		})
+	}
+}
+
+func TestParseArgsCodeError(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-from-code", "ucum:cel", "0", "kelvin"}, "invalid value for -from-code flag"},
+		{[]string{"-to-code", "si:K", "0", "celsius"}, "invalid value for -to-code flag"},
+		{[]string{"-from-code", "ucum:Cel", "0"}, "missing required argument: to scale"},
+		{[]string{"-from-code", "ucum:Cel", "0", "c", "k"}, "supplied too many arguments: k"},
+		{[]string{"-from-code", "ucum:Cel", "-to-code", "ucum:K", "0", "k"}, "supplied too many arguments: k"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseArgs(w, c.args, flags)
+			if err == nil {
+				t.Fatalf("got %v want error", err)
+			}
+
+			if !strings.Contains(w.String(), c.want) {
+				t.Errorf("got %v want to contain %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestParseArgsPolicy(t *testing.T) {
+	var cases = []struct {
+		args   []string
+		policy scale.Policy
+	}{
+		{[]string{"0", "c", "k"}, scale.Policy{}},
+		{[]string{"-nonfinite", "allow", "nan", "c", "k"}, scale.Policy{NonFinite: scale.Allow}},
+		{[]string{"-nonfinite", "clamp", "-inf", "c", "k"}, scale.Policy{NonFinite: scale.Clamp}},
+		{[]string{"-below-zero", "clamp", "-300", "c", "k"}, scale.Policy{AbsoluteZero: scale.Clamp}},
+		{[]string{"-below-zero", "allow", "-300", "c", "k"}, scale.Policy{AbsoluteZero: scale.Allow}},
+		{[]string{"-below-zero", "error", "0", "c", "k"}, scale.Policy{}},
+		{[]string{"-max", "1000", "0", "c", "k"}, scale.Policy{Max: 1000}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if conf.policy != c.policy {
+				t.Errorf("got %+v want %+v", conf.policy, c.policy)
+			}
+		})
+	}
+}
+
+func TestParseArgsPolicyError(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-nonfinite", "ignore", "0", "c", "k"}, "invalid value for -nonfinite flag: ignore"},
+		{[]string{"-below-zero", "wrap", "0", "c", "k"}, "invalid value for -below-zero flag: wrap"},
+		{[]string{"-max", "-1", "0", "c", "k"}, "invalid value for -max flag: -1"},
+		{[]string{"-max", "inf", "0", "c", "k"}, "invalid value for -max flag: +Inf"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseArgs(w, c.args, flags)
+			if err == nil {
+				t.Fatalf("got %v want error", err)
+			}
+
+			if !strings.Contains(w.String(), c.want) {
+				t.Errorf("got %v want to contain %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestSeparateNegatives(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Int("d", 2, "")
+	flags.Bool("u", false, "")
+	flags.Var(&sigFlag{}, "sig", "")
+
+	var cases = []struct {
+		args []string
+		want []string
+	}{
+		{[]string{}, []string{}},
+		{[]string{"0", "c", "k"}, []string{"0", "c", "k"}},
+		{[]string{"-10", "c", "k"}, []string{"--", "-10", "c", "k"}},
+		{[]string{"-u", "-10", "c", "k"}, []string{"-u", "--", "-10", "c", "k"}},
+		{[]string{"-sig", "-10", "c", "k"}, []string{"-sig", "--", "-10", "c", "k"}},
+		{[]string{"-d", "-1", "0", "c", "k"}, []string{"-d", "-1", "0", "c", "k"}},
+		{[]string{"--d", "-1", "0", "c", "k"}, []string{"--d", "-1", "0", "c", "k"}},
+		{[]string{"-d=1", "-10", "c", "k"}, []string{"-d=1", "--", "-10", "c", "k"}},
+		{[]string{"-d", "1", "-10", "c", "k"}, []string{"-d", "1", "--", "-10", "c", "k"}},
+		{[]string{"--", "-10", "c", "k"}, []string{"--", "-10", "c", "k"}},
+		{[]string{"0", "-10", "k"}, []string{"0", "-10", "k"}},
+		{[]string{"-x", "-10", "c", "k"}, []string{"-x", "--", "-10", "c", "k"}},
+		{[]string{"-.5", "c", "k"}, []string{"--", "-.5", "c", "k"}},
+		{[]string{"-inf", "c", "k"}, []string{"--", "-inf", "c", "k"}},
+		{[]string{"-Infinity", "c", "k"}, []string{"--", "-Infinity", "c", "k"}},
+		{[]string{"-info", "c", "k"}, []string{"-info", "c", "k"}},
+		{[]string{"-", "c", "k"}, []string{"-", "c", "k"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			got := separateNegatives(c.args, flags)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
 func TestParseArgsError(t *testing.T) {
 	var cases = []struct {
 		args []string
@@ -60,14 +334,23 @@ func TestParseArgsError(t *testing.T) {
 		{[]string{"0"}},
 		{[]string{"0", "kelvin"}},
 		{[]string{"fifty", "celsius", "kelvin"}},
+		{[]string{"20±", "celsius", "kelvin"}},
+		{[]string{"20±-0.1", "celsius", "kelvin"}},
+		{[]string{"20±0.1±0.2", "celsius", "kelvin"}},
 		{[]string{"0", "celsius", "wedgwood"}},
-		{[]string{"-10", "celsius", "kelvin"}},
 		{[]string{"0", "celsius", "kelvin", "extra"}},
 		{[]string{"-d", "0", "celsius", "kelvin"}},
 		{[]string{"-d", "13", "0", "celsius", "kelvin"}},
 		{[]string{"-d", "-1", "0", "celsius", "kelvin"}},
 		{[]string{"-f", "0", "celsius", "kelvin"}},
 		{[]string{"-h", "-v"}},
+		{[]string{"-sig", "-d", "3", "0", "celsius", "kelvin"}},
+		{[]string{"-sig=-1", "0", "celsius", "kelvin"}},
+		{[]string{"-sig=x", "0", "celsius", "kelvin"}},
+		{[]string{"-u", "-format", "short", "0", "celsius", "kelvin"}},
+		{[]string{"-format", "{{.Out", "0", "celsius", "kelvin"}},
+		{[]string{"-format", "{{.Temp}}", "0", "celsius", "kelvin"}},
+		{[]string{"-calibration", "missing.csv", "0", "celsius", "kelvin"}},
 	}
 
 	for _, c := range cases {
@@ -83,6 +366,32 @@ func TestParseArgsError(t *testing.T) {
 	}
 }
 
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"value", "short", "long", "equation"} {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseFormat(name)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if got != formatPresets[name] {
+				t.Errorf("got %v want preset %v", got.Name(), name)
+			}
+		})
+	}
+
+	t.Run("template", func(t *testing.T) {
+		got, err := parseFormat("{{.Out}} {{.OutName}}")
+		if err != nil {
+			t.Fatalf("got %v want %v", err, nil)
+		}
+
+		if got.Name() != "format" {
+			t.Errorf("got %v want %v", got.Name(), "format")
+		}
+	})
+}
+
 func TestMatchAll(t *testing.T) {
 	slice := []string{
 		"abc",
@@ -127,3 +436,76 @@ func TestFlatten(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArgsCalibration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "probe.csv")
+	err := os.WriteFile(path, []byte("# scale: celsius\nmeasured,reference\n-0.2,0\n100.4,100\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseArgs(w, []string{"-calibration", path, "0", "celsius", "kelvin"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	if conf.calibration == nil || conf.calibration.Sensor != "probe" {
+		t.Errorf("got %v want calibration for probe", conf.calibration)
+	}
+}
+
+func TestParseTemp(t *testing.T) {
+	cases := []struct {
+		s           string
+		temp        float64
+		uncertainty float64
+	}{
+		{"20", 20, 0},
+		{"20.0±0.1", 20, 0.1},
+		{"20.0 ± 0.1", 20, 0.1},
+		{"-10+-0.5", -10, 0.5},
+	}
+
+	for _, c := range cases {
+		t.Run(c.s, func(t *testing.T) {
+			temp, uncertainty, err := parseTemp(c.s)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if temp != c.temp || uncertainty != c.uncertainty {
+				t.Errorf("got %v±%v want %v±%v", temp, uncertainty, c.temp, c.uncertainty)
+			}
+		})
+	}
+}
+
+func TestParseArgsSig(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want int
+	}{
+		{[]string{"100", "fahrenheit", "kelvin"}, 0},
+		{[]string{"-sig", "100", "fahrenheit", "kelvin"}, 3},
+		{[]string{"-sig", "98.60±0.05", "fahrenheit", "kelvin"}, 4},
+		{[]string{"-sig=2", "100", "fahrenheit", "kelvin"}, 2},
+		{[]string{"-sig=false", "100", "fahrenheit", "kelvin"}, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if conf.sig != c.want {
+				t.Errorf("got %v want %v", conf.sig, c.want)
+			}
+		})
+	}
+}
@@ -1,27 +1,50 @@
 package cli
 
 import (
-	"errors"
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
+	"strconv"
 	"text/template"
 
 	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/format"
 	"github.com/solbero/tempconv/scale"
 )
 
-var templateParsed *template.Template
+var (
+	templateParsed *template.Template
+	formatPresets  = map[string]*template.Template{}
+)
+
+// Output format presets. The value format is used by default and the short format with -u.
+const (
+	valueFormat    = `{{.Out}}{{with .OutUncertainty}} ± {{.}}{{end}}`
+	shortFormat    = `{{.Out}}{{with .OutUncertainty}} ± {{.}}{{end}} {{.OutUnit}}`
+	longFormat     = `{{.In}}{{with .InUncertainty}} ± {{.}}{{end}} {{.InName}} is {{.Out}}{{with .OutUncertainty}} ± {{.}}{{end}} {{.OutName}}`
+	equationFormat = `{{.In}}{{with .InUncertainty}} ± {{.}}{{end}} {{.InUnit}} = {{.Out}}{{with .OutUncertainty}} ± {{.}}{{end}} {{.OutUnit}}`
+)
 
 const helpTemplate = `tempconv converts temperatures between different temperature scales.
 
 Usage:
-  tempconv [-u -d <int> | -h | -v] temp from_scale to_scale
+  tempconv [convert] [-u | -format <format>] [-d <int> | -sig[=<int>]] [-calibration <file>] temp from_scale to_scale
+  tempconv [convert] [options] [-from-code <system:code>] [-to-code <system:code>] temp [from_scale] [to_scale]
+  tempconv <command> [options] [arguments]
+  tempconv -h | -v
+
+Commands:
+{{- range .Commands}}
+  {{printf "%-11s" .Name}} {{.Summary}}
+{{- end}}
+
+Run 'tempconv help <command>' for more information on a command.
 
-If temperature is negative, it must be prefixed with '--' to avoid being interpreted as a flag.
+Negative temperatures such as -10 are read as temperatures, not flags.
 
 Arguments:
-  temp        Temperature to convert
+  temp        Temperature to convert, optionally with a standard uncertainty as temp±u or temp+-u
   from_scale  Scale to convert temperature from
   to_scale    Scale to convert temperature to
 
@@ -31,24 +54,63 @@ Scales:
   {{else if $i}}, {{end}}{{$v}}
 {{- end}}{{- end}}
 
-It is possible to use abbreviations as long as it uniquely identifies a scale.
+It is possible to use abbreviations as long as it uniquely identifies a scale. Scale names are
+compared case and diacritic insensitively, and misspelled names get suggestions. Scales can also be
+given as unit symbols such as K, °F, ℃, degC or deg R, where R is rankine and r an abbreviation.
+
+A calibration file is a CSV file with the columns measured and reference and a comment line
+such as '# scale: celsius', or a JSON file with a sensor name, a scale, a correction method
+(linear or polynomial), a polynomial degree of at least 1 and points with measured and
+reference values, all in the scale the sensor reads in. The scale must be from_scale, and the
+uncertainty of temp is scaled by the slope of the correction.
+
+Unit codes replace the scale arguments with a code from the systems ucum (K, Cel, [degF], [degR],
+[degRe]), unece (KEL, CEL, FAH, A48), qudt (K, DEG_C, DEG_F, DEG_R, as name, unit: CURIE or IRI)
+or bacnet (63, 62, 64, or names such as degrees-celsius).
+
+NaN and infinite temperatures and temperatures below absolute zero are rejected by default. Use
+-nonfinite and -below-zero to allow or clamp them instead, and -max to reject temperatures above a
+plausibility bound in kelvin.
+
+Defaults for -d, -u and -format and a default to_scale can be set in a config file or the
+environment, see 'tempconv config -h'.
+
+The output format is either one of the presets value, short, long and equation, or a Go template
+with the fields In, InUncertainty, InUnit, InName, InAlias, Out, OutUncertainty, OutUnit,
+OutName and OutAlias.
 
 Options:
 {{- range .Flags }}
-  -{{ printf "%-2s" .Name}} {{.Usage}}
+  -{{ printf "%-12s" .Name}} {{.Usage}}
 {{- end}}
 
 Examples:
   tempconv 0 celsius kelvin
   tempconv 0 c k
+  tempconv 32 degF K
   tempconv -u -d 4 0 celsius kelvin
-  tempconv -u -- -10 celsius kelvin`
+  tempconv -u -10 celsius kelvin
+  tempconv -u 20.0±0.1 celsius fahrenheit
+  tempconv -sig 100 fahrenheit kelvin
+  tempconv -format equation 0 celsius kelvin
+  tempconv -format '{{"{{"}}.Out{{"}}"}} degrees {{"{{"}}.OutName{{"}}"}}' 0 celsius fahrenheit
+  tempconv -calibration probe.csv 20.3 celsius kelvin
+  tempconv -from-code ucum:Cel -to-code unece:FAH 100
+  tempconv -below-zero allow -300 celsius kelvin
+  tempconv -max 1000 500 celsius kelvin`
 
 func templateData(scales [][]string, flags *flag.FlagSet) (info struct {
-	Scales [][]string
-	Flags  []flag.Flag
+	Scales   [][]string
+	Flags    []flag.Flag
+	Commands []struct{ Name, Summary string }
 }) {
 	info.Scales = scales
+	for _, cmd := range commands {
+		if cmd.hidden {
+			continue
+		}
+		info.Commands = append(info.Commands, struct{ Name, Summary string }{cmd.name, cmd.summary})
+	}
 	info.Flags = func() (l []flag.Flag) {
 		flags.VisitAll(func(f *flag.Flag) {
 			l = append(l, *f)
@@ -58,8 +120,23 @@ func templateData(scales [][]string, flags *flag.FlagSet) (info struct {
 	return info
 }
 
+// outputData holds the fields available to output format templates.
+type outputData struct {
+	In, InUncertainty, InUnit, InName, InAlias      string
+	Out, OutUncertainty, OutUnit, OutName, OutAlias string
+}
+
 func init() {
 	templateParsed = template.Must(template.New("tempconv").Parse(helpTemplate))
+
+	for name, text := range map[string]string{
+		"value":    valueFormat,
+		"short":    shortFormat,
+		"long":     longFormat,
+		"equation": equationFormat,
+	} {
+		formatPresets[name] = template.Must(template.New(name).Parse(text))
+	}
 }
 
 func Run(w io.Writer, conf *config, flags *flag.FlagSet, version string) (err error) {
@@ -74,24 +151,76 @@ func Run(w io.Writer, conf *config, flags *flag.FlagSet, version string) (err er
 		return nil
 	}
 
+	conf.input.Policy = conf.policy
+	conf.output.Policy = conf.policy
+
 	err = conf.input.SetTemp(conf.temp)
 	if err != nil {
-		err := errors.Unwrap(err)
-		fprinte(w, err.Error())
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	err = conf.input.SetUncertainty(conf.uncertainty)
+	if err != nil {
+		fprinte(w, errorMessage(err))
 		return err
 	}
 
+	if conf.calibration != nil {
+		err = conf.calibration.Apply(conf.input)
+		if err != nil {
+			fprinte(w, errorMessage(err))
+			return err
+		}
+	}
+
 	err = convert.Convert(conf.input, conf.output)
 	if err != nil {
-		err := errors.Unwrap(err)
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	tmpl := conf.format
+	if tmpl == nil && conf.unit {
+		tmpl = formatPresets["short"]
+	} else if tmpl == nil {
+		tmpl = formatPresets["value"]
+	}
+
+	data := outputData{
+		In:       formatValue(conf, conf.input.Temp()),
+		InUnit:   conf.input.Unit,
+		InName:   conf.input.Name,
+		InAlias:  conf.input.Alias,
+		Out:      formatValue(conf, conf.output.Temp()),
+		OutUnit:  conf.output.Unit,
+		OutName:  conf.output.Name,
+		OutAlias: conf.output.Alias,
+	}
+
+	if conf.uncertainty != 0 {
+		data.InUncertainty = formatValue(conf, conf.input.Uncertainty())
+		data.OutUncertainty = formatValue(conf, conf.output.Uncertainty())
+	}
+
+	// Execute into a buffer, so a failing template does not leave partial output
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
 		fprinte(w, err.Error())
 		return err
 	}
 
-	if conf.unit {
-		fmt.Fprintf(w, "%.*f %s", conf.decimal, conf.output.Temp(), conf.output.Unit)
-	} else {
-		fmt.Fprintf(w, "%.*f", conf.decimal, conf.output.Temp())
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// formatValue formats v to the significant figures or decimal places of conf.
+func formatValue(conf *config, v float64) string {
+	if conf.sig > 0 {
+		s, _ := format.Sig(v, conf.sig)
+		return s
 	}
-	return nil
+
+	return strconv.FormatFloat(v, 'f', conf.decimal, 64)
 }
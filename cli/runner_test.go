@@ -5,11 +5,19 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 
+	"github.com/solbero/tempconv/calibration"
 	"github.com/solbero/tempconv/scale"
 )
 
+var probe, _ = calibration.New("probe", "celsius", calibration.Linear, 0, []calibration.Point{
+	{Measured: -0.2, Reference: 0},
+	{Measured: 100.4, Reference: 100},
+})
+
 func TestRun(t *testing.T) {
 	var cases = []struct {
 		config *config
@@ -19,6 +27,13 @@ func TestRun(t *testing.T) {
 		{&config{temp: 0, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2}, "32.00"},
 		{&config{temp: 0, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2, unit: true}, "32.00 °F"},
 		{&config{temp: -273.15, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2}, "0.00"},
+		{&config{temp: 20, uncertainty: 0.1, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2}, "68.00 ± 0.18"},
+		{&config{temp: 20, uncertainty: 0.1, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2, unit: true}, "68.00 ± 0.18 °F"},
+		{&config{temp: 100, input: scale.NewFahrenheit(), output: scale.NewKelvin(), decimal: 2, sig: 3}, "311"},
+		{&config{temp: 100, input: scale.NewFahrenheit(), output: scale.NewKelvin(), decimal: 2, sig: 3, unit: true}, "311 K"},
+		{&config{temp: 20, uncertainty: 0.1, input: scale.NewCelsius(), output: scale.NewFahrenheit(), sig: 3}, "68.0 ± 0.180"},
+		{&config{temp: 100.4, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2, calibration: probe}, "373.15"},
+		{&config{temp: 100.4, uncertainty: 0.503, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 4, calibration: probe}, "373.1500 ± 0.5000"},
 	}
 
 	for _, c := range cases {
@@ -37,6 +52,58 @@ func TestRun(t *testing.T) {
 
 }
 
+func TestRunFormat(t *testing.T) {
+	var cases = []struct {
+		format string
+		want   string
+	}{
+		{"short", "68.00 ± 0.18 °F"},
+		{"long", "20.00 ± 0.10 celsius is 68.00 ± 0.18 fahrenheit"},
+		{"equation", "20.00 ± 0.10 °C = 68.00 ± 0.18 °F"},
+		{"{{.In}} {{.InUnit}} -> {{.Out}} {{.OutUnit}}", "20.00 °C -> 68.00 °F"},
+		{"{{.OutName}} {{.OutAlias}}", "fahrenheit "},
+	}
+
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			tmpl, err := parseFormat(c.format)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			conf := &config{temp: 20, uncertainty: 0.1, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2, format: tmpl}
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			err = Run(w, conf, flags, flags.Name())
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+			if w.String() != c.want {
+				t.Errorf("got %v want %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestRunFormatError(t *testing.T) {
+	// The template only fails on real output data, so it passes parseFormat
+	tmpl, err := parseFormat("{{.Out}}{{if .Out}}{{index .Out 99}}{{end}}")
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	conf := &config{temp: 20, input: scale.NewCelsius(), output: scale.NewFahrenheit(), decimal: 2, format: tmpl}
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	err = Run(w, conf, flags, flags.Name())
+	if err == nil {
+		t.Errorf("got %v want error", err)
+	}
+	if strings.HasPrefix(w.String(), "68.00") {
+		t.Errorf("got %q want no partial output", w.String())
+	}
+}
+
 func TestRunHelp(t *testing.T) {
 	conf := &config{help: true}
 
@@ -73,6 +140,18 @@ func TestRunError(t *testing.T) {
 		{"absolute zero error",
 			&config{temp: -300, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2},
 			scale.ErrAbsoluteZero},
+		{"non-finite error",
+			&config{temp: math.NaN(), input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2},
+			scale.ErrNonFinite},
+		{"implausible error",
+			&config{temp: 1000, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2, policy: scale.Policy{Max: 1000}},
+			scale.ErrImplausible},
+		{"extrapolation error",
+			&config{temp: 200, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2, calibration: probe},
+			calibration.ErrExtrapolation},
+		{"calibration scale error",
+			&config{temp: 50, input: scale.NewFahrenheit(), output: scale.NewKelvin(), decimal: 2, calibration: probe},
+			calibration.ErrScaleMismatch},
 	}
 
 	for _, c := range cases {
@@ -89,3 +168,18 @@ func TestRunError(t *testing.T) {
 		})
 	}
 }
+
+func TestRunErrorMessage(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf := &config{temp: -300, input: scale.NewCelsius(), output: scale.NewKelvin(), decimal: 2}
+	err := Run(w, conf, flags, flags.Name())
+	if err == nil {
+		t.Fatalf("got %v want error", err)
+	}
+
+	want := "temperature below absolute zero: -300 °C, absolute zero is -273.15 °C\n"
+	if !strings.HasPrefix(w.String(), want) {
+		t.Errorf("got %v want prefix %v", w.String(), want)
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+var scalesTemplateParsed *template.Template
+
+const scalesHelpTemplate = `tempconv scales lists the supported temperature scales with their aliases and units.
+
+Usage:
+  tempconv scales [-h]
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}`
+
+type scalesConfig struct {
+	help bool
+}
+
+func init() {
+	scalesTemplateParsed = template.Must(template.New("scales").Parse(scalesHelpTemplate))
+}
+
+func ParseScalesArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *scalesConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	conf = &scalesConfig{}
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(args)
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	err = checkArgs(flags.Args(), []string{})
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func RunScales(w io.Writer, conf *scalesConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(nil, flags)
+		scalesTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "name\talias\tunit")
+	for _, s := range scale.All() {
+		fmt.Fprintf(tw, "\n%s\t%s\t%s", s.Name, s.Alias, s.Unit)
+	}
+	return tw.Flush()
+}
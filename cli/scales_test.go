@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestScales(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseScalesArgs(w, []string{}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunScales(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+
+	lines := strings.Split(w.String(), "\n")
+	want := [][]string{
+		{"name", "alias", "unit"},
+		{"kelvin", "K"},
+		{"celsius", "°C"},
+		{"fahrenheit", "°F"},
+		{"rankine", "°R"},
+		{"delisle", "°De"},
+		{"newton", "°N"},
+		{"réaumur", "reaumur", "°Ré"},
+		{"rømer", "romer", "°Rø"},
+	}
+
+	if len(lines) != len(want) {
+		t.Fatalf("got %v lines want %v", len(lines), len(want))
+	}
+
+	for i := range want {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("got %v want %v", got, want[i])
+		}
+	}
+}
+
+func TestScalesHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseScalesArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunScales(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestScalesError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{"extra"}},
+		{[]string{"-u"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseScalesArgs(w, c.args, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
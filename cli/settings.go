@@ -0,0 +1,325 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+const (
+	sourceDefault = "default"
+	sourceFile    = "config file"
+	sourceEnv     = "environment"
+)
+
+// settings are the CLI defaults which can be set in the config file and environment,
+// mapped to the flags they set, with the defaults of settings without a flag. The output
+// setting is the default to_scale argument, and the errors setting the format of errors.
+var settings = []struct {
+	name  string
+	flag  string
+	value string
+}{
+	{"decimal", "d", ""},
+	{"unit", "u", ""},
+	{"format", "format", ""},
+	{"output", "", ""},
+	{"errors", "", "text"},
+}
+
+// errorFormats are the valid values of the errors setting.
+var errorFormats = []string{"text", "json"}
+
+var configTemplateParsed *template.Template
+
+const configHelpTemplate = `tempconv config shows the effective settings and their sources.
+
+Usage:
+  tempconv config [-h]
+
+Settings are read from a JSON config file, for example {"decimal": 4, "unit": true}, and
+from environment variables. Environment variables take precedence over the config file, and
+flags take precedence over both. Invalid settings are reported after the effective settings.
+
+Config file:
+  $TEMPCONV_CONFIG, $XDG_CONFIG_HOME/tempconv/config.json or ~/.config/tempconv/config.json
+
+Settings:
+  decimal     Number of decimal places, as -d       [env: TEMPCONV_DECIMAL]
+  unit        Include temperature unit, as -u       [env: TEMPCONV_UNIT]
+  format      Output format, as -format             [env: TEMPCONV_FORMAT]
+  output      Default scale to convert temperature to [env: TEMPCONV_OUTPUT]
+  errors      Format of errors, text or json [default: text] [env: TEMPCONV_ERRORS]
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}`
+
+type setting struct {
+	name   string
+	value  string
+	source string
+}
+
+type configConfig struct {
+	help bool
+}
+
+func init() {
+	configTemplateParsed = template.Must(template.New("config").Parse(configHelpTemplate))
+}
+
+func ParseConfigArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *configConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	conf = &configConfig{}
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(args)
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	err = checkArgs(flags.Args(), []string{})
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func RunConfig(w io.Writer, conf *configConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(nil, flags)
+		configTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	defaults := flag.NewFlagSet("tempconv", flag.ContinueOnError)
+	defineFlags(defaults, &config{})
+
+	loaded, err := loadSettings(defaults)
+	if err != nil {
+		fprinte(w, err.Error())
+		return err
+	}
+
+	var invalid []error
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, s := range loaded {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s", s.name, s.value, s.source)
+
+		if s.source != sourceDefault {
+			if err := checkSetting(s); err != nil {
+				invalid = append(invalid, err)
+			}
+		}
+	}
+	tw.Flush()
+
+	// Invalid settings are shown with the others and reported after them
+	if err = errors.Join(invalid...); err != nil {
+		fmt.Fprintln(w)
+		fprinte(w, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// applySettings sets the flags in flags which were not set explicitly to the settings from the
+// config file and environment, so explicit flags win. It must be called after flags.Parse, and
+// returns the value of the output setting. Settings are not loaded if help was asked for, so the
+// help does not depend on them.
+func applySettings(flags *flag.FlagSet) (output string, err error) {
+	if isFlagSet(flags, "h") {
+		return "", nil
+	}
+
+	loaded, err := loadSettings(flags)
+	if err != nil {
+		return "", err
+	}
+
+	for i, s := range loaded {
+		if s.source == sourceDefault || isFlagSet(flags, settings[i].flag) {
+			continue
+		} else if s.name == "output" {
+			// The output setting is only used without a to_scale argument, and checked then
+			output = s.value
+			continue
+		}
+
+		if err = checkSetting(s); err != nil {
+			return "", err
+		}
+
+		if f := flags.Lookup(settings[i].flag); f != nil {
+			// Setting the value directly does not mark the flag as explicitly set
+			f.Value.Set(s.value)
+		}
+	}
+
+	return output, nil
+}
+
+// checkSetting returns an error if the value of the setting s is invalid.
+func checkSetting(s setting) error {
+	invalid := func(reason string) error {
+		msg := fmt.Sprintf("invalid value for %s setting from %s: %s", s.name, s.source, s.value)
+		if reason != "" {
+			msg += ", " + reason
+		}
+		return errors.New(msg)
+	}
+
+	conf := &config{}
+	flags := flag.NewFlagSet("tempconv", flag.ContinueOnError)
+	defineFlags(flags, conf)
+
+	switch s.name {
+	case "decimal":
+		if err := flags.Set("d", s.value); err != nil {
+			return invalid("")
+		} else if checkDecimal(conf.decimal) != nil {
+			return invalid("must be between 0 and 12")
+		}
+	case "unit":
+		if err := flags.Set("u", s.value); err != nil {
+			return invalid("")
+		}
+	case "format":
+		if _, err := parseFormat(s.value); err != nil {
+			return invalid(err.Error())
+		}
+	case "output":
+		if _, err := parseScale(s.value); err != nil {
+			return invalid(err.Error())
+		}
+	case "errors":
+		if !slices.Contains(errorFormats, s.value) {
+			return invalid("must be one of: " + strings.Join(errorFormats, ", "))
+		}
+	}
+
+	return nil
+}
+
+// loadSettings returns the settings with their effective values and sources,
+// taking the default values from the flags in defaults.
+func loadSettings(defaults *flag.FlagSet) ([]setting, error) {
+	path, explicit := configPath()
+	file, err := readConfigFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		file, err = map[string]string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	loaded := make([]setting, len(settings))
+	for i, s := range settings {
+		loaded[i] = setting{name: s.name, value: s.value, source: sourceDefault}
+		if f := defaults.Lookup(s.flag); f != nil {
+			loaded[i].value = f.DefValue
+		}
+
+		if v, ok := file[s.name]; ok {
+			loaded[i].value = v
+			loaded[i].source = fmt.Sprintf("%s %s", sourceFile, path)
+		}
+
+		env := "TEMPCONV_" + strings.ToUpper(s.name)
+		if v, ok := os.LookupEnv(env); ok {
+			loaded[i].value = v
+			loaded[i].source = fmt.Sprintf("%s %s", sourceEnv, env)
+		}
+	}
+
+	return loaded, nil
+}
+
+// errorsSetting returns the format of errors, which is text unless the errors setting is json.
+// The environment is used even if the config file cannot be read, so that error can be reported.
+func errorsSetting() string {
+	value := os.Getenv("TEMPCONV_ERRORS")
+	if loaded, err := loadSettings(flag.NewFlagSet("tempconv", flag.ContinueOnError)); err == nil {
+		for _, s := range loaded {
+			if s.name == "errors" {
+				value = s.value
+			}
+		}
+	}
+
+	if value == "json" {
+		return "json"
+	}
+
+	return "text"
+}
+
+// configPath returns the path of the config file, and whether it was given explicitly with TEMPCONV_CONFIG.
+func configPath() (path string, explicit bool) {
+	if path = os.Getenv("TEMPCONV_CONFIG"); path != "" {
+		return path, true
+	}
+
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "tempconv", "config.json"), false
+	}
+
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "tempconv", "config.json"), false
+}
+
+// readConfigFile reads the settings in the JSON config file at path as strings.
+func readConfigFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	file := map[string]string{}
+	for key, v := range raw {
+		known := false
+		for _, s := range settings {
+			known = known || s.name == key
+		}
+
+		if !known {
+			names := make([]string, len(settings))
+			for i, s := range settings {
+				names[i] = s.name
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown setting: %s, must be one of: %s", key, strings.Join(names, ", "))
+		}
+
+		file[key] = fmt.Sprint(v)
+	}
+
+	return file, nil
+}
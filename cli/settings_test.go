@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain isolates the tests from the config file and environment of the user. With
+// TEMPCONV_TEST_MAIN set, the test binary runs as tempconv instead, for tests of shell scripts.
+func TestMain(m *testing.M) {
+	if os.Getenv("TEMPCONV_TEST_MAIN") != "" {
+		os.Exit(Main(os.Stdout, os.Stderr, os.Args[1:], "test"))
+	}
+
+	dir, err := os.MkdirTemp("", "tempconv")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	for _, name := range []string{"CONFIG", "DECIMAL", "UNIT", "FORMAT", "OUTPUT", "ERRORS"} {
+		os.Unsetenv("TEMPCONV_" + name)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEMPCONV_CONFIG", path)
+	return path
+}
+
+func TestLoadSettings(t *testing.T) {
+	path := writeConfig(t, `{"decimal": 4, "unit": true}`)
+	t.Setenv("TEMPCONV_UNIT", "false")
+	t.Setenv("TEMPCONV_OUTPUT", "kelvin")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	defineFlags(flags, &config{})
+	got, err := loadSettings(flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	want := []setting{
+		{"decimal", "4", "config file " + path},
+		{"unit", "false", "environment TEMPCONV_UNIT"},
+		{"format", "", "default"},
+		{"output", "kelvin", "environment TEMPCONV_OUTPUT"},
+		{"errors", "text", "default"},
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v want %v", got[i], want[i])
+		}
+	}
+}
+
+func TestParseArgsSettings(t *testing.T) {
+	var cases = []struct {
+		config string
+		env    map[string]string
+		args   []string
+		want   string
+	}{
+		{`{"decimal": 4, "unit": true}`, nil, []string{"0", "c", "k"}, "273.1500 K"},
+		{`{"decimal": 4}`, map[string]string{"TEMPCONV_DECIMAL": "1"}, []string{"0", "c", "k"}, "273.1"},
+		{`{"decimal": 4}`, map[string]string{"TEMPCONV_DECIMAL": "1"}, []string{"-d", "0", "0", "c", "k"}, "273"},
+		{`{"output": "fahrenheit"}`, nil, []string{"0", "c"}, "32.00"},
+		{`{"output": "fahrenheit"}`, nil, []string{"0", "c", "k"}, "273.15"},
+		{`{"format": "equation"}`, nil, []string{"0", "c", "k"}, "0.00 °C = 273.15 K"},
+		{`{"format": "equation"}`, nil, []string{"-u", "0", "c", "k"}, "273.15 K"},
+		{`{"unit": true}`, nil, []string{"-format", "long", "0", "c", "k"}, "0.00 celsius is 273.15 kelvin"},
+		{`{"decimal": 4}`, nil, []string{"-sig", "0.0", "c", "k"}, "300"},
+		{`{"decimal": "x"}`, map[string]string{"TEMPCONV_DECIMAL": "x"}, []string{"-d", "2", "0", "c", "k"}, "273.15"},
+		{`{"unit": "maybe"}`, nil, []string{"-u", "0", "c", "k"}, "273.15 K"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.config+" "+strings.Join(c.args, " "), func(t *testing.T) {
+			writeConfig(t, c.config)
+			for k, v := range c.env {
+				t.Setenv(k, v)
+			}
+
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = Run(w, conf, flags, flags.Name())
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+			if w.String() != c.want {
+				t.Errorf("got %v want %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestParseArgsSettingsError(t *testing.T) {
+	var cases = []struct {
+		name   string
+		config string
+		env    map[string]string
+	}{
+		{"invalid json", `{"decimal": `, nil},
+		{"unknown setting", `{"colour": "red"}`, nil},
+		{"invalid file value", `{"unit": "maybe"}`, nil},
+		{"invalid env value", `{}`, map[string]string{"TEMPCONV_DECIMAL": "four"}},
+		{"decimal out of range", `{"decimal": 20}`, nil},
+		{"invalid format", `{"format": "{{.Out"}`, nil},
+		{"unknown format field", `{}`, map[string]string{"TEMPCONV_FORMAT": "{{.Temp}}"}},
+		{"invalid errors file value", `{"errors": "xml"}`, nil},
+		{"invalid errors env value", `{}`, map[string]string{"TEMPCONV_ERRORS": "xml"}},
+		{"missing file", "", map[string]string{"TEMPCONV_CONFIG": "missing.json"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			writeConfig(t, c.config)
+			for k, v := range c.env {
+				t.Setenv(k, v)
+			}
+
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseArgs(w, []string{"0", "c", "k"}, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestParseArgsSettingsHelp(t *testing.T) {
+	writeConfig(t, `{"decimal": `)
+	t.Setenv("TEMPCONV_DECIMAL", "four")
+
+	for _, args := range [][]string{{"-h"}, {"-u", "-d", "3", "--h"}, {"-format=short", "-h"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseArgs(w, args, flags)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if !conf.help {
+				t.Errorf("got %v want %v", conf.help, true)
+			}
+		})
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	path := writeConfig(t, `{"unit": true}`)
+	t.Setenv("TEMPCONV_DECIMAL", "4")
+
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseConfigArgs(w, []string{}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunConfig(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+
+	lines := strings.Split(w.String(), "\n")
+	want := [][]string{
+		{"decimal", "4", "environment", "TEMPCONV_DECIMAL"},
+		{"unit", "true", "config", "file", path},
+		{"format", "default"},
+		{"output", "default"},
+		{"errors", "text", "default"},
+	}
+
+	for i := range want {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("got %v want %v", got, want[i])
+		}
+	}
+}
+
+func TestRunConfigInvalid(t *testing.T) {
+	writeConfig(t, `{"unit": "maybe"}`)
+	t.Setenv("TEMPCONV_DECIMAL", "20")
+	t.Setenv("TEMPCONV_OUTPUT", "foo")
+
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseConfigArgs(w, []string{}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunConfig(w, conf, flags)
+	if err == nil {
+		t.Fatalf("got %v want error", err)
+	}
+
+	for _, want := range []string{
+		"invalid value for decimal setting from environment TEMPCONV_DECIMAL: 20, must be between 0 and 12",
+		"invalid value for unit setting from config file",
+		"invalid value for output setting from environment TEMPCONV_OUTPUT: foo",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("got %v want %v", w.String(), want)
+		}
+	}
+
+	if lines := strings.Split(w.String(), "\n"); !strings.HasPrefix(lines[0], "decimal") {
+		t.Errorf("got %v want %v", lines[0], "the settings first")
+	}
+}
+
+func TestRunConfigHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseConfigArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunConfig(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestParseConfigArgsError(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := ParseConfigArgs(w, []string{"extra"}, flags)
+	if err == nil {
+		t.Errorf("got %v want error", err)
+	}
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+var tableTemplateParsed *template.Template
+
+const tableHelpTemplate = `tempconv table shows a temperature converted to every supported scale.
+
+Usage:
+  tempconv table [-u -d <int>] temp from_scale
+
+Arguments:
+  temp        Temperature to convert, optionally with a standard uncertainty as temp±u or temp+-u
+  from_scale  Scale to convert temperature from
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}
+
+Examples:
+  tempconv table 100 celsius
+  tempconv table -u -d 4 -40 f`
+
+type tableConfig struct {
+	temp        float64
+	uncertainty float64
+	input       *scale.Scale
+	decimal     int
+	unit        bool
+	help        bool
+}
+
+func init() {
+	tableTemplateParsed = template.Must(template.New("table").Parse(tableHelpTemplate))
+}
+
+func ParseTableArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *tableConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	conf = &tableConfig{}
+	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
+	flags.BoolVar(&conf.unit, "u", false, "Include temperature unit")
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(separateNegatives(args, flags))
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	_, err = applySettings(flags)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	if conf.help {
+		return conf, nil
+	}
+
+	err = checkDecimal(conf.decimal)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Check non-flag arguments
+	nonFlagArgs := flags.Args()
+	err = checkArgs(nonFlagArgs, []string{"temp", "from scale"})
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Parse non-flag arguments
+	conf.temp, conf.uncertainty, err = parseTemp(nonFlagArgs[0])
+	if err != nil {
+		return nil, tempArgError(w, nonFlagArgs[0], err)
+	}
+
+	conf.input, err = parseScale(nonFlagArgs[1])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func RunTable(w io.Writer, conf *tableConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(nil, flags)
+		tableTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	err = conf.input.SetTemp(conf.temp)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	err = conf.input.SetUncertainty(conf.uncertainty)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, output := range scale.All() {
+		err = convert.Convert(conf.input, output)
+		if err != nil {
+			fprinte(w, errorMessage(err))
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s", output.Name, strconv.FormatFloat(output.Temp(), 'f', conf.decimal, 64))
+		if conf.uncertainty != 0 {
+			fmt.Fprintf(tw, " ± %s", strconv.FormatFloat(output.Uncertainty(), 'f', conf.decimal, 64))
+		}
+		if conf.unit {
+			fmt.Fprintf(tw, " %s", output.Unit)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want [][]string
+	}{
+		{[]string{"100", "celsius"}, [][]string{
+			{"kelvin", "373.15"},
+			{"celsius", "100.00"},
+			{"fahrenheit", "212.00"},
+			{"rankine", "671.67"},
+			{"delisle", "0.00"},
+			{"newton", "33.00"},
+			{"réaumur", "80.00"},
+			{"rømer", "60.00"},
+		}},
+		{[]string{"-40", "f"}, [][]string{
+			{"kelvin", "233.15"},
+			{"celsius", "-40.00"},
+			{"fahrenheit", "-40.00"},
+			{"rankine", "419.67"},
+			{"delisle", "210.00"},
+			{"newton", "-13.20"},
+			{"réaumur", "-32.00"},
+			{"rømer", "-13.50"},
+		}},
+		{[]string{"-u", "-d", "1", "0±0.5", "c"}, [][]string{
+			{"kelvin", "273.1", "±", "0.5", "K"},
+			{"celsius", "0.0", "±", "0.5", "°C"},
+			{"fahrenheit", "32.0", "±", "0.9", "°F"},
+			{"rankine", "491.7", "±", "0.9", "°R"},
+			{"delisle", "150.0", "±", "0.8", "°De"},
+			{"newton", "0.0", "±", "0.2", "°N"},
+			{"réaumur", "0.0", "±", "0.4", "°Ré"},
+			{"rømer", "7.5", "±", "0.3", "°Rø"},
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseTableArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunTable(w, conf, flags)
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+
+			lines := strings.Split(w.String(), "\n")
+			if len(lines) != len(c.want) {
+				t.Fatalf("got %v lines want %v", len(lines), len(c.want))
+			}
+
+			for i := range c.want {
+				if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(c.want[i], " ") {
+					t.Errorf("got %v want %v", got, c.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTableHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseTableArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunTable(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestTableError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{}},
+		{[]string{"100"}},
+		{[]string{"100", "c", "k"}},
+		{[]string{"hot", "c"}},
+		{[]string{"100", "wedgwood"}},
+		{[]string{"-d", "13", "100", "c"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseTableArgs(w, c.args, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestTableRunError(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseTableArgs(w, []string{"--", "-300", "c"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunTable(w, conf, flags)
+	if err == nil {
+		t.Errorf("got %v want error", err)
+	}
+}
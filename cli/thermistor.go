@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/solbero/tempconv/scale"
+	"github.com/solbero/tempconv/thermistor"
+)
+
+var thermistorTemplateParsed *template.Template
+
+const thermistorHelpTemplate = `tempconv thermistor converts between NTC thermistor resistance and temperature.
+
+Usage:
+  tempconv thermistor [-u -d <int>] (-sh <A,B,C> | -beta <R0,T0,B>) resistance to_scale
+  tempconv thermistor [-u -d <int>] (-sh <A,B,C> | -beta <R0,T0,B>) -r temp from_scale
+
+Arguments:
+  resistance  Resistance in ohms to convert
+  temp        Temperature to convert
+  to_scale    Scale to convert resistance to
+  from_scale  Scale to convert temperature from
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-4s" .Name}} {{.Usage}}
+{{- end}}
+
+Examples:
+  tempconv thermistor -beta 10000,25,3950 12000 celsius
+  tempconv thermistor -sh 1.009e-3,2.378e-4,2.019e-7 -r 25 c`
+
+type thermistorConfig struct {
+	value   float64
+	model   thermistor.Model
+	scale   *scale.Scale
+	reverse bool
+	decimal int
+	unit    bool
+	help    bool
+}
+
+func init() {
+	thermistorTemplateParsed = template.Must(template.New("thermistor").Parse(thermistorHelpTemplate))
+}
+
+func ParseThermistorArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *thermistorConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	var sh, beta string
+	conf = &thermistorConfig{}
+	flags.StringVar(&sh, "sh", "", "Steinhart–Hart coefficients A,B,C")
+	flags.StringVar(&beta, "beta", "", "Beta model parameters R0,T0,β with R0 in ohms and T0 in celsius")
+	flags.BoolVar(&conf.reverse, "r", false, "Convert temperature to resistance")
+	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
+	flags.BoolVar(&conf.unit, "u", false, "Include unit")
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(separateNegatives(args, flags))
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	_, err = applySettings(flags)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	if conf.help {
+		return conf, nil
+	}
+
+	err = checkDecimal(conf.decimal)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Parse thermistor model
+	conf.model, err = parseModel(sh, beta)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Check non-flag arguments
+	required := []string{"resistance", "to scale"}
+	if conf.reverse {
+		required = []string{"temp", "from scale"}
+	}
+
+	nonFlagArgs := flags.Args()
+	err = checkArgs(nonFlagArgs, required)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Parse non-flag arguments
+	conf.value, err = strconv.ParseFloat(nonFlagArgs[0], 64)
+	if err != nil {
+		msg := fmt.Sprintf("invalid value for %s argument: %s", required[0], nonFlagArgs[0])
+		fprinte(w, msg)
+		return nil, numberError(msg)
+	}
+
+	conf.scale, err = parseScale(nonFlagArgs[1])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func RunThermistor(w io.Writer, conf *thermistorConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(scale.ScaleNames(), flags)
+		thermistorTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	if conf.reverse {
+		err = conf.scale.SetTemp(conf.value)
+		if err != nil {
+			fprinte(w, errorMessage(err))
+			return err
+		}
+
+		r, err := conf.model.Resistance(conf.scale)
+		if err != nil {
+			fprinte(w, errorMessage(err))
+			return err
+		}
+
+		if conf.unit {
+			fmt.Fprintf(w, "%.*f Ω", conf.decimal, r)
+		} else {
+			fmt.Fprintf(w, "%.*f", conf.decimal, r)
+		}
+		return nil
+	}
+
+	err = conf.model.Temperature(conf.value, conf.scale)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	if conf.unit {
+		fmt.Fprintf(w, "%.*f %s", conf.decimal, conf.scale.Temp(), conf.scale.Unit)
+	} else {
+		fmt.Fprintf(w, "%.*f", conf.decimal, conf.scale.Temp())
+	}
+	return nil
+}
+
+func parseModel(sh, beta string) (thermistor.Model, error) {
+	if sh == "" && beta == "" {
+		return nil, errors.New("missing thermistor model: -sh or -beta")
+	} else if sh != "" && beta != "" {
+		return nil, errors.New("mutually exclusive flags: -sh, -beta")
+	}
+
+	if sh != "" {
+		v, err := parseFloats(sh, 3)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for -sh flag: %s", sh)
+		}
+		return thermistor.SteinhartHart{A: v[0], B: v[1], C: v[2]}, nil
+	}
+
+	v, err := parseFloats(beta, 3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for -beta flag: %s", beta)
+	}
+
+	t0 := scale.NewCelsius()
+	err = t0.SetTemp(v[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for -beta flag: %s", beta)
+	}
+
+	return thermistor.Beta{R0: v[0], T0: t0, Beta: v[2]}, nil
+}
+
+func parseFloats(s string, n int) ([]float64, error) {
+	fields := strings.Split(s, ",")
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d comma separated values: %s", n, s)
+	}
+
+	v := make([]float64, n)
+	for i, f := range fields {
+		var err error
+		v[i], err = strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
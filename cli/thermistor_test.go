@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestThermistor(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-beta", "10000,25,3950", "10000", "celsius"}, "25.00"},
+		{[]string{"-beta", "10000,25,3950", "-u", "10000", "kelvin"}, "298.15 K"},
+		{[]string{"-beta", "10000,25,3950", "-r", "25", "c"}, "10000.00"},
+		{[]string{"-beta", "10000,25,3950", "-r", "-u", "-d", "0", "25", "c"}, "10000 Ω"},
+		{[]string{"-sh", "1.009249522e-3,2.378405444e-4,2.019202697e-7", "-d", "4", "10000", "c"}, "24.6813"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseThermistorArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunThermistor(w, conf, flags)
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+			if w.String() != c.want {
+				t.Errorf("got %v want %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestThermistorHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseThermistorArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunThermistor(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestThermistorError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{"10000", "celsius"}},
+		{[]string{"-sh", "1,2", "10000", "celsius"}},
+		{[]string{"-beta", "10000,25,x", "10000", "celsius"}},
+		{[]string{"-sh", "1,2,3", "-beta", "10000,25,3950", "10000", "celsius"}},
+		{[]string{"-beta", "10000,25,3950", "10000"}},
+		{[]string{"-beta", "10000,25,3950", "ten", "celsius"}},
+		{[]string{"-beta", "10000,25,3950", "10000", "wedgwood"}},
+		{[]string{"-beta", "10000,25,3950", "-d", "13", "10000", "celsius"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseThermistorArgs(w, c.args, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestThermistorRunError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{"-beta", "10000,25,3950", "0", "celsius"}},
+		{[]string{"-beta", "10000,25,3950", "-r", "--", "-300", "celsius"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseThermistorArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunThermistor(w, conf, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
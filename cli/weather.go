@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/solbero/tempconv/scale"
+	"github.com/solbero/tempconv/weather"
+)
+
+var (
+	weatherTemplateParsed *template.Template
+	weatherIndices        = []string{"heatindex", "windchill", "dewpoint", "humidex"}
+)
+
+const weatherHelpTemplate = `tempconv weather computes meteorological indices from a temperature.
+
+Usage:
+  tempconv weather [-u -d <int>] (-rh <float> | -w <float>) index temp from_scale to_scale
+
+Arguments:
+  index       Index to compute
+  temp        Air temperature
+  from_scale  Scale of the air temperature
+  to_scale    Scale to return the index in
+
+Indices:
+  heatindex   NWS heat index, requires -rh
+  windchill   NWS/Environment Canada wind chill, requires -w
+  dewpoint    Dew point, requires -rh
+  humidex     Environment Canada humidex, requires -rh
+
+It is possible to use abbreviations as long as it uniquely identifies an index.
+
+Options:
+{{- range .Flags }}
+  -{{ printf "%-2s" .Name}} {{.Usage}}
+{{- end}}
+
+Examples:
+  tempconv weather -rh 60 heatindex 90 f f
+  tempconv weather -w 30 windchill -10 c c`
+
+type weatherConfig struct {
+	index    string
+	temp     float64
+	input    *scale.Scale
+	output   *scale.Scale
+	humidity float64
+	wind     float64
+	decimal  int
+	unit     bool
+	help     bool
+}
+
+func init() {
+	weatherTemplateParsed = template.Must(template.New("weather").Parse(weatherHelpTemplate))
+}
+
+func ParseWeatherArgs(w io.Writer, args []string, flags *flag.FlagSet) (conf *weatherConfig, err error) {
+	flags.SetOutput(w)
+	flags.Usage = func() {}
+
+	// Parse flags
+	conf = &weatherConfig{}
+	flags.Float64Var(&conf.humidity, "rh", 0, "Relative humidity in percent")
+	flags.Float64Var(&conf.wind, "w", 0, "Wind speed in km/h")
+	flags.IntVar(&conf.decimal, "d", 2, "Number of decimal places [default: 2, min: 0, max: 12]")
+	flags.BoolVar(&conf.unit, "u", false, "Include temperature unit")
+	flags.BoolVar(&conf.help, "h", false, "Show help and exit")
+
+	err = flags.Parse(separateNegatives(args, flags))
+	if err != nil {
+		fmt.Fprint(w, usageMsg) // flag.Parse already prints an error
+		return nil, err
+	}
+
+	_, err = applySettings(flags)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	if conf.help {
+		return conf, nil
+	}
+
+	err = checkDecimal(conf.decimal)
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Check non-flag arguments
+	nonFlagArgs := flags.Args()
+	err = checkArgs(nonFlagArgs, []string{"index", "temp", "from scale", "to scale"})
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Parse non-flag arguments
+	conf.index, err = parseIndex(nonFlagArgs[0])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	conf.temp, err = strconv.ParseFloat(nonFlagArgs[1], 64)
+	if err != nil {
+		msg := fmt.Sprintf("invalid value for temp argument: %s", nonFlagArgs[1])
+		fprinte(w, msg)
+		return nil, numberError(msg)
+	}
+
+	conf.input, err = parseScale(nonFlagArgs[2])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	conf.output, err = parseScale(nonFlagArgs[3])
+	if err != nil {
+		fprinte(w, err.Error())
+		return nil, err
+	}
+
+	// Check index inputs
+	var msg string
+	if conf.index == "windchill" && !isFlagSet(flags, "w") {
+		msg = fmt.Sprintf("missing required flag for %s: -w", conf.index)
+	} else if conf.index != "windchill" && !isFlagSet(flags, "rh") {
+		msg = fmt.Sprintf("missing required flag for %s: -rh", conf.index)
+	}
+
+	if msg != "" {
+		fprinte(w, msg)
+		return nil, errors.New(msg)
+	}
+
+	return conf, nil
+}
+
+func RunWeather(w io.Writer, conf *weatherConfig, flags *flag.FlagSet) (err error) {
+	if conf.help {
+		data := templateData(scale.ScaleNames(), flags)
+		weatherTemplateParsed.Execute(w, data)
+		return nil
+	}
+
+	err = conf.input.SetTemp(conf.temp)
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	switch conf.index {
+	case "heatindex":
+		err = weather.HeatIndex(conf.input, conf.humidity, conf.output)
+	case "windchill":
+		err = weather.WindChill(conf.input, conf.wind, conf.output)
+	case "dewpoint":
+		err = weather.DewPoint(conf.input, conf.humidity, conf.output)
+	case "humidex":
+		err = weather.Humidex(conf.input, conf.humidity, conf.output)
+	default:
+		err = fmt.Errorf("unknown index: %s", conf.index)
+	}
+
+	if err != nil {
+		fprinte(w, errorMessage(err))
+		return err
+	}
+
+	if conf.unit {
+		fmt.Fprintf(w, "%.*f %s", conf.decimal, conf.output.Temp(), conf.output.Unit)
+	} else {
+		fmt.Fprintf(w, "%.*f", conf.decimal, conf.output.Temp())
+	}
+	return nil
+}
+
+func parseIndex(name string) (string, error) {
+	matches := matchAll(name, weatherIndices)
+
+	if len(matches) == 0 {
+		return "", fmt.Errorf("unknown weather index: %s", name)
+	} else if len(matches) > 1 {
+		return "", fmt.Errorf("ambiguous weather index: %s, matches: %s", name, strings.Join(matches, ", "))
+	}
+
+	return matches[0], nil
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) (set bool) {
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
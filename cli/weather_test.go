@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestWeather(t *testing.T) {
+	var cases = []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-rh", "60", "heatindex", "90", "f", "f"}, "99.68"},
+		{[]string{"-rh", "60", "-u", "-d", "0", "heat", "90", "f", "f"}, "100 °F"},
+		{[]string{"-w", "30", "--", "windchill", "-10", "c", "c"}, "-19.52"},
+		{[]string{"-rh", "50", "d", "20", "c", "c"}, "9.26"},
+		{[]string{"-rh", "70", "humidex", "30", "c", "c"}, "41.20"},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseWeatherArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunWeather(w, conf, flags)
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+			if w.String() != c.want {
+				t.Errorf("got %v want %v", w.String(), c.want)
+			}
+		})
+	}
+}
+
+func TestWeatherHelp(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf, err := ParseWeatherArgs(w, []string{"-h"}, flags)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = RunWeather(w, conf, flags)
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestWeatherError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{}},
+		{[]string{"-rh", "60", "heatindex", "90", "f"}},
+		{[]string{"-rh", "60", "heat", "90", "f", "f", "extra"}},
+		{[]string{"-rh", "60", "h", "90", "f", "f"}},
+		{[]string{"-rh", "60", "comfort", "90", "f", "f"}},
+		{[]string{"-rh", "60", "heatindex", "ninety", "f", "f"}},
+		{[]string{"-rh", "60", "heatindex", "90", "wedgwood", "f"}},
+		{[]string{"heatindex", "90", "f", "f"}},
+		{[]string{"-rh", "60", "windchill", "0", "c", "c"}},
+		{[]string{"-rh", "60", "-d", "13", "heatindex", "90", "f", "f"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			_, err := ParseWeatherArgs(w, c.args, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
+
+func TestWeatherRunError(t *testing.T) {
+	var cases = []struct {
+		args []string
+	}{
+		{[]string{"-rh", "101", "heatindex", "90", "f", "f"}},
+		{[]string{"-w", "30", "windchill", "20", "c", "c"}},
+		{[]string{"-rh", "60", "--", "dewpoint", "-300", "c", "c"}},
+	}
+
+	for _, c := range cases {
+		t.Run(strings.Join(c.args, " "), func(t *testing.T) {
+			w := new(bytes.Buffer)
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			conf, err := ParseWeatherArgs(w, c.args, flags)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			err = RunWeather(w, conf, flags)
+			if err == nil {
+				t.Errorf("got %v want error", err)
+			}
+		})
+	}
+}
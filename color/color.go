@@ -0,0 +1,143 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+const (
+	minRGBKelvin = 1000.0
+	maxRGBKelvin = 40000.0
+)
+
+var (
+	ErrOutOfRange          = fmt.Errorf("color temperature must be between %g K and %g K", minRGBKelvin, maxRGBKelvin)
+	ErrInvalidMired        = errors.New("mired value must be positive")
+	ErrInvalidChromaticity = errors.New("chromaticity coordinates must be between 0 and 1")
+)
+
+// RGB is an sRGB color.
+type RGB struct {
+	R, G, B uint8
+}
+
+// Hex returns the color as a hexadecimal string, for example "#ffa757".
+func (c RGB) Hex() string    { return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B) }
+func (c RGB) String() string { return fmt.Sprintf("%d,%d,%d", c.R, c.G, c.B) }
+
+// ToRGB returns the approximate sRGB color of a blackbody at the temperature of t.
+// It uses Tanner Helland's fit of the Planckian locus, valid between 1000 K and 40000 K.
+func ToRGB(t *scale.Scale) (c RGB, err error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return c, err
+	}
+
+	if k < minRGBKelvin || k > maxRGBKelvin {
+		return c, fmt.Errorf("tempconv: %w", ErrOutOfRange)
+	}
+
+	k /= 100
+	r, g, b := 255.0, 255.0, 255.0
+
+	if k > 66 {
+		r = 329.698727446 * math.Pow(k-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(k-60, -0.0755148492)
+	} else {
+		g = 99.4708025861*math.Log(k) - 161.1195681661
+	}
+
+	if k <= 19 {
+		b = 0
+	} else if k < 66 {
+		b = 138.5177312231*math.Log(k-10) - 305.0447927307
+	}
+
+	return RGB{R: clamp(r), G: clamp(g), B: clamp(b)}, nil
+}
+
+// ToMired returns the temperature of t in mireds (micro reciprocal degrees).
+func ToMired(t *scale.Scale) (float64, error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	if k == 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrInvalidMired)
+	}
+
+	return 1e6 / k, nil
+}
+
+// FromMired sets out to the temperature corresponding to m mireds.
+func FromMired(m float64, out *scale.Scale) error {
+	if m <= 0 {
+		return fmt.Errorf("tempconv: %w", ErrInvalidMired)
+	}
+
+	return setKelvin(1e6/m, out)
+}
+
+// McCamy sets out to the correlated color temperature of the CIE 1931 chromaticity x, y
+// using McCamy's cubic approximation.
+func McCamy(x, y float64, out *scale.Scale) error {
+	if err := checkChromaticity(x, y); err != nil {
+		return err
+	}
+
+	n := (x - 0.3320) / (0.1858 - y)
+	return setKelvin(449*n*n*n+3525*n*n+6823.3*n+5520.33, out)
+}
+
+// HernandezAndres sets out to the correlated color temperature of the CIE 1931 chromaticity x, y
+// using the exponential approximation of Hernández-Andrés et al., valid between 3000 K and 800000 K.
+func HernandezAndres(x, y float64, out *scale.Scale) error {
+	if err := checkChromaticity(x, y); err != nil {
+		return err
+	}
+
+	n := (x - 0.3366) / (y - 0.1735)
+	k := -949.86315 + 6253.80338*math.Exp(-n/0.92159) + 28.70599*math.Exp(-n/0.20039) + 0.00004*math.Exp(-n/0.07125)
+
+	if k > 50000 {
+		n = (x - 0.3356) / (y - 0.1691)
+		k = 36284.48953 + 0.00228*math.Exp(-n/0.07861) + 5.4535e-36*math.Exp(-n/0.01543)
+	}
+
+	return setKelvin(k, out)
+}
+
+func checkChromaticity(x, y float64) error {
+	if x <= 0 || x >= 1 || y <= 0 || y >= 1 {
+		return fmt.Errorf("tempconv: %w", ErrInvalidChromaticity)
+	}
+
+	return nil
+}
+
+func clamp(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(255, v))))
+}
+
+func kelvin(s *scale.Scale) (float64, error) {
+	k := scale.NewKelvin()
+	if err := convert.Convert(s, k); err != nil {
+		return 0, err
+	}
+
+	return k.Temp(), nil
+}
+
+func setKelvin(t float64, out *scale.Scale) error {
+	k := scale.NewKelvin()
+	if err := k.SetTemp(t); err != nil {
+		return err
+	}
+
+	return convert.Convert(k, out)
+}
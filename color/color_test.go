@@ -0,0 +1,160 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+func TestToRGB(t *testing.T) {
+	cases := []struct {
+		temp float64
+		want RGB
+		hex  string
+	}{
+		{1900, RGB{255, 132, 0}, "#ff8400"},
+		{2700, RGB{255, 167, 87}, "#ffa757"},
+		{6600, RGB{255, 255, 255}, "#ffffff"},
+		{10000, RGB{202, 218, 255}, "#cadaff"},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g K", c.temp), func(t *testing.T) {
+			k := scale.NewKelvin()
+			err := k.SetTemp(c.temp)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			got, err := ToRGB(k)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+
+			if got.Hex() != c.hex {
+				t.Errorf("got %v want %v", got.Hex(), c.hex)
+			}
+		})
+	}
+}
+
+func TestMired(t *testing.T) {
+	cases := []struct {
+		temp  float64
+		mired float64
+	}{
+		{2000, 500},
+		{4000, 250},
+		{6500, 153.84615384615384},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g K", c.temp), func(t *testing.T) {
+			k := scale.NewKelvin()
+			err := k.SetTemp(c.temp)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			got, err := ToMired(k)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(got-c.mired) > 1e-9 {
+				t.Errorf("got %v want %v", got, c.mired)
+			}
+
+			k = scale.NewKelvin()
+			err = FromMired(c.mired, k)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(k.Temp()-c.temp) > 1e-9 {
+				t.Errorf("got %v want %v", k.Temp(), c.temp)
+			}
+		})
+	}
+}
+
+func TestCCT(t *testing.T) {
+	cases := []struct {
+		name   string
+		method func(x, y float64, out *scale.Scale) error
+		x, y   float64
+		want   float64
+	}{
+		{"mccamy D65", McCamy, 0.31271, 0.32902, 6504.389383},
+		{"mccamy A", McCamy, 0.44757, 0.40745, 2857.289613},
+		{"hernandez-andres D65", HernandezAndres, 0.31271, 0.32902, 6500.042153},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := scale.NewKelvin()
+			err := c.method(c.x, c.y, k)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(k.Temp()-c.want) > 1e-6 {
+				t.Errorf("got %v want %v", k.Temp(), c.want)
+			}
+		})
+	}
+}
+
+func TestTemperatureError(t *testing.T) {
+	cases := []struct {
+		name string
+		temp float64
+		f    func(*scale.Scale) error
+		want error
+	}{
+		{"rgb too cold", 500, func(s *scale.Scale) error { _, err := ToRGB(s); return err }, ErrOutOfRange},
+		{"rgb too hot", 50000, func(s *scale.Scale) error { _, err := ToRGB(s); return err }, ErrOutOfRange},
+		{"mired of absolute zero", 0, func(s *scale.Scale) error { _, err := ToMired(s); return err }, ErrInvalidMired},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := scale.NewKelvin()
+			err := k.SetTemp(c.temp)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			err = c.f(k)
+			if !errors.Is(err, c.want) {
+				t.Errorf("got %v want %v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"negative mired", FromMired(-1, scale.NewKelvin()), ErrInvalidMired},
+		{"chromaticity", McCamy(1.2, 0.3, scale.NewKelvin()), ErrInvalidChromaticity},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.want) {
+				t.Errorf("got %v want %v", c.err, c.want)
+			}
+		})
+	}
+}
@@ -3,98 +3,134 @@ package convert
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/solbero/tempconv/scale"
 )
 
-var ErrScaleNotSupported = errors.New("scale not supported")
+// ErrScaleNotSupported is an error type for scales of an unknown type.
+//
+// Deprecated: Use scale.ErrUnknownScale, which ErrScaleNotSupported is equal to.
+var ErrScaleNotSupported = scale.ErrUnknownScale
 
-// InvalidConversionError is an error type for invalid temperature conversions.
+// InvalidConversionError is an error type for invalid temperature conversions. Value is the
+// offending value in kelvin and Bound the bound in kelvin it violated, or NaN if it violated none.
+// Err is one of the sentinel errors of the scale package.
 type InvalidConversionError struct {
-	input  *scale.Scale
-	output *scale.Scale
-	err    error
+	Input  *scale.Scale
+	Output *scale.Scale
+	Value  float64
+	Bound  float64
+	Err    error
 }
 
 func (ic InvalidConversionError) Error() string {
-	return fmt.Sprintf("invalid conversion from %s to %s: %s", ic.input.Name, ic.output.Name, ic.err.Error())
+	msg := fmt.Sprintf("invalid conversion from %s to %s", scaleName(ic.Input), scaleName(ic.Output))
+	if ic.Err != nil {
+		msg += ": " + ic.Err.Error()
+	}
+	if ic.Err != nil && !math.IsNaN(ic.Bound) {
+		msg += fmt.Sprintf(": %g K, bound %g K", ic.Value, ic.Bound)
+	}
+	return msg
+}
+
+func (ic InvalidConversionError) Unwrap() error { return ic.Err }
+
+// scaleName returns the name of s, or nil scale if s is nil.
+func scaleName(s *scale.Scale) string {
+	if s == nil {
+		return "nil scale"
+	}
+
+	return s.Name
 }
 
 // Convert converts a temperature from a temperature scale to another.
-// It returns an error if the conversion is not possible.scale.
+// The standard uncertainty of the temperature is propagated along with it.
+// The temperature is validated with the policy of input, and then of output.
+// It returns an error wrapping an InvalidConversionError if the conversion is not possible.
 func Convert(input, output *scale.Scale) (err error) {
+	if input == nil || output == nil {
+		return fmt.Errorf("tempconv: %w: nil scale", scale.ErrUnknownScale)
+	}
+
 	k := scale.NewKelvin()
+	k.Policy = input.Policy
 
-	if err = kelvinFrom(input, k); err != nil {
-		return err
+	if v, err := kelvinFrom(input, k); err != nil {
+		return invalidConversion(input, output, input.Policy, v, err)
 	}
 
-	if err = kelvinTo(output, k); err != nil {
-		return err
+	if v, err := kelvinTo(output, k); err != nil {
+		return invalidConversion(input, output, output.Policy, v, err)
 	}
 
 	return nil
 }
 
-func kelvinFrom(s, k *scale.Scale) (err error) {
-	var t float64
-
-	switch s.Type {
-	case scale.KELVIN:
-		t = s.Temp()
-	case scale.CELSIUS:
-		t = s.Temp() + 273.15
-	case scale.FAHRENHEIT:
-		t = (s.Temp()*5 + 459.67*5) / 9
-	case scale.RANKINE:
-		t = s.Temp() * 5 / 9
-	case scale.DELISLE:
-		t = (373.15*3 - s.Temp()*2) / 3
-	case scale.NEWTON:
-		t = (s.Temp()*100 + 273.15*33) / 33
-	case scale.REAUMUR:
-		t = (s.Temp()*5 + 273.15*4) / 4
-	case scale.ROMER:
-		t = (s.Temp()*40 - 7.5*40 + 273.15*21) / 21
-	default:
-		panic(fmt.Errorf("tempconv: %w", InvalidConversionError{input: s, output: k, err: ErrScaleNotSupported}))
+// invalidConversion returns an InvalidConversionError for the policy which was violated.
+func invalidConversion(input, output *scale.Scale, policy scale.Policy, v float64, err error) error {
+	bound := math.NaN()
+	if errors.Is(err, scale.ErrAbsoluteZero) {
+		bound = scale.NewKelvin().AbsoluteZero()
+	} else if errors.Is(err, scale.ErrImplausible) {
+		bound = policy.Max
+	}
+
+	return fmt.Errorf("tempconv: %w", InvalidConversionError{Input: input, Output: output, Value: v, Bound: bound, Err: err})
+}
+
+// sentinels are the errors of the scale package which an InvalidConversionError can wrap.
+var sentinels = []error{scale.ErrUnknownScale, scale.ErrAbsoluteZero, scale.ErrNonFinite, scale.ErrNegativeUncertainty, scale.ErrImplausible}
+
+// sentinel returns the sentinel error of the scale package which err wraps.
+func sentinel(err error) error {
+	for _, s := range sentinels {
+		if errors.Is(err, s) {
+			return s
+		}
+	}
+
+	return err
+}
+
+// kelvinFrom sets k to the temperature of s. On error it returns the offending value in kelvin.
+func kelvinFrom(s, k *scale.Scale) (v float64, err error) {
+	t, m, err := s.ToKelvin(s.Temp())
+	if err != nil {
+		return math.NaN(), sentinel(err)
 	}
 
 	err = k.SetTemp(t)
 	if err != nil {
-		return fmt.Errorf("tempconv: %w", InvalidConversionError{input: s, output: k, err: errors.Unwrap(err)})
+		return t, sentinel(err)
 	}
 
-	return nil
+	u := s.Uncertainty() * math.Abs(m) // Delisle scale is inverted
+	if err = k.SetUncertainty(u); err != nil {
+		return u, sentinel(err)
+	}
+
+	return 0, nil
 }
 
-func kelvinTo(s, k *scale.Scale) (err error) {
-	var t float64
-
-	switch s.Type {
-	case scale.KELVIN:
-		t = k.Temp()
-	case scale.CELSIUS:
-		t = k.Temp() - 273.15
-	case scale.FAHRENHEIT:
-		t = (k.Temp()*9 - 459.67*5) / 5
-	case scale.RANKINE:
-		t = k.Temp() * 9 / 5
-	case scale.DELISLE:
-		t = (373.15 - k.Temp()) * 3 / 2
-	case scale.NEWTON:
-		t = (k.Temp() - 273.15) * 33 / 100
-	case scale.REAUMUR:
-		t = (k.Temp()*4 - 273.15*4) / 5
-	case scale.ROMER:
-		t = ((k.Temp()*21 - 273.15*21) + 7.5*40) / 40
-	default:
-		panic(fmt.Errorf("tempconv: %w", InvalidConversionError{input: s, output: k, err: ErrScaleNotSupported}))
+// kelvinTo sets s to the temperature of k. On error it returns the offending value in kelvin.
+func kelvinTo(s, k *scale.Scale) (v float64, err error) {
+	t, m, err := s.FromKelvin(k.Temp())
+	if err != nil {
+		return math.NaN(), sentinel(err)
 	}
+
 	err = s.SetTemp(t)
 	if err != nil {
-		return fmt.Errorf("tempconv: %w", InvalidConversionError{input: k, output: s, err: errors.Unwrap(err)})
+		return k.Temp(), sentinel(err)
 	}
 
-	return nil
+	u := k.Uncertainty() * math.Abs(m) // Delisle scale is inverted
+	if err = s.SetUncertainty(u); err != nil {
+		return k.Uncertainty(), sentinel(err)
+	}
+
+	return 0, nil
 }
@@ -1,6 +1,7 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"testing"
@@ -86,3 +87,187 @@ func assertAlmostEqual(got, want, epsilon float64) bool {
 
 	return diff/math.Min(sum, math.MaxFloat64) < epsilon
 }
+
+func TestUncertaintyPropagation(t *testing.T) {
+	cases := []struct {
+		input  *scale.Scale
+		output *scale.Scale
+		u      float64
+		want   float64
+	}{
+		{scale.NewCelsius(), scale.NewKelvin(), 0.1, 0.1},
+		{scale.NewCelsius(), scale.NewFahrenheit(), 0.1, 0.18},
+		{scale.NewFahrenheit(), scale.NewCelsius(), 0.18, 0.1},
+		{scale.NewKelvin(), scale.NewRankine(), 1, 1.8},
+		{scale.NewCelsius(), scale.NewDelisle(), 1, 1.5},
+		{scale.NewDelisle(), scale.NewCelsius(), 1.5, 1},
+		{scale.NewCelsius(), scale.NewNewton(), 1, 0.33},
+		{scale.NewCelsius(), scale.NewReaumur(), 1, 0.8},
+		{scale.NewCelsius(), scale.NewRomer(), 1, 0.525},
+		{scale.NewRomer(), scale.NewNewton(), 0.525, 0.33},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("±%g %v -> ±%g %v", c.u, c.input.Name, c.want, c.output.Name), func(t *testing.T) {
+			err := c.input.SetTemp(20)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			err = c.input.SetUncertainty(c.u)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			err = Convert(c.input, c.output)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			got := c.output.Uncertainty()
+			if math.Abs(got-c.want) > scale.EqualityThresholdFloat64 {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestConvertError(t *testing.T) {
+	huge := scale.NewKelvin()
+	huge.SetTemp(math.MaxFloat64)
+
+	cases := []struct {
+		name   string
+		input  *scale.Scale
+		output *scale.Scale
+		err    error
+		value  float64
+	}{
+		{"unknown input", &scale.Scale{Type: 99, Name: "wedgwood"}, scale.NewKelvin(), scale.ErrUnknownScale, math.NaN()},
+		{"unknown output", scale.NewKelvin(), &scale.Scale{Type: 99, Name: "wedgwood"}, scale.ErrUnknownScale, math.NaN()},
+		{"overflow", huge, scale.NewFahrenheit(), scale.ErrNonFinite, math.MaxFloat64},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := Convert(c.input, c.output)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("got %v want %v", err, c.err)
+			}
+
+			var ic InvalidConversionError
+			if !errors.As(err, &ic) {
+				t.Fatalf("got %T want %T", err, ic)
+			}
+
+			if ic.Input != c.input || ic.Output != c.output {
+				t.Errorf("got %v to %v want %v to %v", ic.Input, ic.Output, c.input, c.output)
+			}
+
+			if !(ic.Value == c.value || math.IsNaN(ic.Value) && math.IsNaN(c.value)) {
+				t.Errorf("got %v want %v", ic.Value, c.value)
+			}
+
+			if !math.IsNaN(ic.Bound) {
+				t.Errorf("got %v want %v", ic.Bound, math.NaN())
+			}
+		})
+	}
+}
+
+func TestConvertNil(t *testing.T) {
+	err := Convert(nil, scale.NewKelvin())
+	if !errors.Is(err, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, scale.ErrUnknownScale)
+	}
+
+	err = Convert(scale.NewKelvin(), nil)
+	if !errors.Is(err, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, scale.ErrUnknownScale)
+	}
+}
+
+func TestInvalidConversionError(t *testing.T) {
+	cases := []struct {
+		err  InvalidConversionError
+		want string
+	}{
+		{InvalidConversionError{scale.NewCelsius(), scale.NewKelvin(), -1e-9, 0, scale.ErrAbsoluteZero},
+			"invalid conversion from celsius to kelvin: temperature below absolute zero: -1e-09 K, bound 0 K"},
+		{InvalidConversionError{scale.NewKelvin(), scale.NewFahrenheit(), math.MaxFloat64, math.NaN(), scale.ErrNonFinite},
+			"invalid conversion from kelvin to fahrenheit: value must be finite"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.want, func(t *testing.T) {
+			if c.err.Error() != c.want {
+				t.Errorf("got %v want %v", c.err.Error(), c.want)
+			}
+
+			if !errors.Is(c.err, c.err.Err) {
+				t.Errorf("got %v want %v", c.err, c.err.Err)
+			}
+		})
+	}
+}
+
+func TestInvalidConversionErrorZero(t *testing.T) {
+	want := "invalid conversion from nil scale to nil scale"
+	if got := (InvalidConversionError{}).Error(); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestErrScaleNotSupported(t *testing.T) {
+	if !errors.Is(ErrScaleNotSupported, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", ErrScaleNotSupported, scale.ErrUnknownScale)
+	}
+}
+
+func TestConvertPolicy(t *testing.T) {
+	input, output := scale.NewCelsius(), scale.NewKelvin()
+	input.Policy.AbsoluteZero = scale.Allow
+	output.Policy.AbsoluteZero = scale.Allow
+
+	err := input.SetTemp(-300)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	err = Convert(input, output)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	if math.Abs(output.Temp()-(-26.85)) > scale.EqualityThresholdFloat64 {
+		t.Errorf("got %v want %v", output.Temp(), -26.85)
+	}
+
+	// The policy of output still applies
+	output.Policy = scale.Policy{}
+	err = Convert(input, output)
+	var ic InvalidConversionError
+	if !errors.As(err, &ic) || !errors.Is(err, scale.ErrAbsoluteZero) {
+		t.Fatalf("got %v want %v", err, scale.ErrAbsoluteZero)
+	}
+
+	if ic.Bound != 0 {
+		t.Errorf("got %v want %v", ic.Bound, 0)
+	}
+}
+
+func TestConvertPolicyImplausible(t *testing.T) {
+	input, output := scale.NewCelsius(), scale.NewFahrenheit()
+	input.SetTemp(100)
+	output.Policy.Max = 350
+
+	err := Convert(input, output)
+	var ic InvalidConversionError
+	if !errors.As(err, &ic) || !errors.Is(err, scale.ErrImplausible) {
+		t.Fatalf("got %v want %v", err, scale.ErrImplausible)
+	}
+
+	if ic.Bound != 350 || math.Abs(ic.Value-373.15) > scale.EqualityThresholdFloat64 {
+		t.Errorf("got %v, %v want %v, %v", ic.Value, ic.Bound, 373.15, 350)
+	}
+}
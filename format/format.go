@@ -0,0 +1,80 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidLiteral = errors.New("invalid numeric literal")
+	ErrInvalidSigFigs = errors.New("significant figures must be positive")
+)
+
+// SigFigs returns the number of significant figures in the numeric literal s.
+// Leading zeros are not significant. Trailing zeros are, including those of a number
+// without a decimal point, as a temperature of 100 is rarely meant as 1 × 10².
+// A literal with only zeros has as many significant figures as it has decimals, at least one.
+func SigFigs(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return 0, fmt.Errorf("tempconv: %w: %s", ErrInvalidLiteral, s)
+	}
+
+	// Strip sign and exponent
+	s = strings.TrimLeft(s, "+-")
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		s = s[:i]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return int(math.Max(1, float64(len(fracPart)))), nil
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("tempconv: %w: %s", ErrInvalidLiteral, s)
+		}
+	}
+
+	return len(digits), nil
+}
+
+// Round returns v rounded to sig significant figures.
+func Round(v float64, sig int) float64 {
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) || sig < 1 {
+		return v
+	}
+
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'e', sig-1, 64), 64)
+	return r
+}
+
+// Sig formats v with sig significant figures in decimal notation, for example 310.928 with 3 as "311"
+// and 0.0012345 with 2 as "0.0012".
+func Sig(v float64, sig int) (string, error) {
+	if sig < 1 {
+		return "", fmt.Errorf("tempconv: %w", ErrInvalidSigFigs)
+	}
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+
+	r := Round(v, sig)
+	if r == 0 {
+		return strconv.FormatFloat(0, 'f', sig-1, 64), nil
+	}
+
+	exp := int(math.Floor(math.Log10(math.Abs(r))))
+	decimals := sig - 1 - exp
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	return strconv.FormatFloat(r, 'f', decimals, 64), nil
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSigFigs(t *testing.T) {
+	cases := []struct {
+		literal string
+		want    int
+	}{
+		{"100", 3},
+		{"100.0", 4},
+		{"-40", 2},
+		{"+7", 1},
+		{"0.0012", 2},
+		{"0.00120", 3},
+		{"98.6", 3},
+		{"1.50e2", 3},
+		{"6.02E-3", 3},
+		{".5", 1},
+		{"5.", 1},
+		{"0", 1},
+		{"0.00", 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.literal, func(t *testing.T) {
+			got, err := SigFigs(c.literal)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSigFigsError(t *testing.T) {
+	for _, literal := range []string{"", "abc", "1,5", "NaN", "Inf", "0x1p4"} {
+		t.Run(literal, func(t *testing.T) {
+			_, err := SigFigs(literal)
+			if !errors.Is(err, ErrInvalidLiteral) {
+				t.Errorf("got %v want %v", err, ErrInvalidLiteral)
+			}
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		v    float64
+		sig  int
+		want float64
+	}{
+		{310.92777, 3, 311},
+		{310.92777, 5, 310.93},
+		{-459.67, 2, -460},
+		{0.0012345, 2, 0.0012},
+		{0, 3, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g:%d", c.v, c.sig), func(t *testing.T) {
+			got := Round(c.v, c.sig)
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSig(t *testing.T) {
+	cases := []struct {
+		v    float64
+		sig  int
+		want string
+	}{
+		{310.92777, 3, "311"},
+		{310.92777, 4, "310.9"},
+		{31092.777, 2, "31000"},
+		{0.0012345, 2, "0.0012"},
+		{9.996, 3, "10.0"},
+		{-40, 2, "-40"},
+		{273.15, 1, "300"},
+		{0, 3, "0.00"},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g:%d", c.v, c.sig), func(t *testing.T) {
+			got, err := Sig(c.v, c.sig)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSigError(t *testing.T) {
+	_, err := Sig(1, 0)
+	if !errors.Is(err, ErrInvalidSigFigs) {
+		t.Errorf("got %v want %v", err, ErrInvalidSigFigs)
+	}
+}
@@ -0,0 +1,183 @@
+// Command gendocs renders the tempconv man page and Markdown CLI reference from the flag sets of
+// the commands and the supported scales. Run it with go generate from the repository root.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/solbero/tempconv/cli"
+	"github.com/solbero/tempconv/scale"
+)
+
+const (
+	manFile      = "tempconv.1"
+	markdownFile = "cli.md"
+)
+
+func main() {
+	dir := flag.String("dir", "docs", "Directory to write the documentation to")
+	flag.Parse()
+
+	err := generate(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gendocs:", err)
+		os.Exit(1)
+	}
+}
+
+func generate(dir string) error {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	for name, render := range map[string]func(io.Writer, []cli.Doc, [][]string, []cli.ExitStatus){
+		manFile:      renderMan,
+		markdownFile: renderMarkdown,
+	} {
+		buff := new(bytes.Buffer)
+		render(buff, cli.Docs(), scale.ScaleNames(), cli.ExitStatuses)
+
+		err = os.WriteFile(filepath.Join(dir, name), buff.Bytes(), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderMan(w io.Writer, docs []cli.Doc, scales [][]string, statuses []cli.ExitStatus) {
+	fmt.Fprintln(w, `.\" Code generated by gendocs. DO NOT EDIT.`)
+	fmt.Fprintln(w, `.TH TEMPCONV 1 "" "tempconv" "User Commands"`)
+	fmt.Fprintln(w, ".SH NAME")
+	fmt.Fprintln(w, `tempconv \- convert temperatures between temperature scales`)
+
+	fmt.Fprintln(w, ".SH SYNOPSIS")
+	fmt.Fprintln(w, ".nf")
+	for _, line := range docs[0].Usage {
+		fmt.Fprintln(w, roff(line))
+	}
+	fmt.Fprintln(w, ".fi")
+
+	fmt.Fprintln(w, ".SH DESCRIPTION")
+	fmt.Fprintln(w, "tempconv converts temperatures between different temperature scales.")
+	fmt.Fprintln(w, "Without a command, the arguments are passed to the convert command.")
+	fmt.Fprintln(w, "Scales can be abbreviated as long as the abbreviation uniquely identifies a scale.")
+
+	fmt.Fprintln(w, ".SH COMMANDS")
+	for _, doc := range docs {
+		fmt.Fprintln(w, ".SS "+roff(doc.Name))
+		fmt.Fprintln(w, roff(doc.Summary)+".")
+		fmt.Fprintln(w, ".PP")
+		fmt.Fprintln(w, ".nf")
+		for _, line := range doc.Usage {
+			fmt.Fprintln(w, roff(line))
+		}
+		fmt.Fprintln(w, ".fi")
+
+		for _, f := range doc.Flags {
+			name, usage := flag.UnquoteUsage(f)
+			fmt.Fprintln(w, ".TP")
+			if name == "" {
+				fmt.Fprintln(w, ".B "+roff("-"+f.Name))
+			} else {
+				fmt.Fprintf(w, ".BI %s \" <%s>\"\n", roff("-"+f.Name), roff(name))
+			}
+			fmt.Fprintln(w, roff(usage))
+		}
+	}
+
+	fmt.Fprintln(w, ".SH SCALES")
+	for _, names := range scales {
+		fmt.Fprintln(w, ".TP")
+		fmt.Fprintln(w, ".B "+roff(names[0]))
+		if len(names) > 1 {
+			fmt.Fprintln(w, "Also "+roff(strings.Join(names[1:], ", "))+".")
+		} else {
+			fmt.Fprintln(w, "No alternative spellings.")
+		}
+	}
+
+	fmt.Fprintln(w, ".SH EXIT STATUS")
+	for _, s := range statuses {
+		fmt.Fprintln(w, ".TP")
+		fmt.Fprintf(w, ".B %d\n", s.Code)
+		fmt.Fprintf(w, "%s (%s).\n", roff(s.Description), roff(s.Kind))
+	}
+	fmt.Fprintln(w, ".PP")
+	fmt.Fprintln(w, "With the errors setting set to json, for example with TEMPCONV_ERRORS=json, errors are written")
+	fmt.Fprintln(w, "to standard error as a JSON object with the fields code, kind and message.")
+}
+
+func renderMarkdown(w io.Writer, docs []cli.Doc, scales [][]string, statuses []cli.ExitStatus) {
+	fmt.Fprintln(w, "<!-- Code generated by gendocs. DO NOT EDIT. -->")
+	fmt.Fprintln(w, "# tempconv CLI reference")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "tempconv converts temperatures between different temperature scales. Without a command, the arguments are passed to the `convert` command.")
+
+	for _, doc := range docs {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "## "+doc.Name)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, doc.Summary+".")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "```sh")
+		for _, line := range doc.Usage {
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w, "```")
+
+		if len(doc.Flags) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "**Options**")
+			fmt.Fprintln(w)
+		}
+		for _, f := range doc.Flags {
+			name, usage := flag.UnquoteUsage(f)
+			if name == "" {
+				fmt.Fprintf(w, "* `-%s`: %s\n", f.Name, usage)
+			} else {
+				fmt.Fprintf(w, "* `-%s <%s>`: %s\n", f.Name, name, usage)
+			}
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "## Scales")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Scales can be abbreviated as long as the abbreviation uniquely identifies a scale.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Scale | Also |")
+	fmt.Fprintln(w, "| --- | --- |")
+	for _, names := range scales {
+		fmt.Fprintf(w, "| %s | %s |\n", names[0], strings.Join(names[1:], ", "))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "## Exit status")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Code | Kind | Description |")
+	fmt.Fprintln(w, "| --- | --- | --- |")
+	for _, s := range statuses {
+		fmt.Fprintf(w, "| %d | %s | %s |\n", s.Code, s.Kind, s.Description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "With the `errors` setting set to `json`, for example with `TEMPCONV_ERRORS=json`, errors are written to standard error as a JSON object with the fields `code`, `kind` and `message`.")
+}
+
+// roff escapes s for use as text in a roff document.
+func roff(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
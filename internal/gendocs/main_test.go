@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedUpToDate fails when the committed documentation differs from what gendocs renders.
+// Run 'go generate' from the repository root to update it.
+func TestGeneratedUpToDate(t *testing.T) {
+	dir := t.TempDir()
+	err := generate(dir)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	for _, name := range []string{manFile, markdownFile} {
+		t.Run(name, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join("..", "..", "docs", name))
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			want, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("docs/%s is out of date, run 'go generate'", name)
+			}
+		})
+	}
+}
+
+func TestRoff(t *testing.T) {
+	var cases = []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{"-d <int>", `\-d <int>`},
+		{`a\b`, `a\eb`},
+		{".start", `\&.start`},
+		{"'quote", `\&'quote`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got := roff(c.in)
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package linalg solves the small linear systems used for fitting calibration coefficients.
+package linalg
+
+import "math"
+
+// tolerance is the size of a pivot of the scaled system below which the system is treated as
+// singular.
+const tolerance = 1e-12
+
+// Solve solves the n×n linear system given as an augmented matrix of n rows of n+1 columns using
+// Gaussian elimination with partial pivoting. The columns and rows are scaled to a largest
+// coefficient of 1 first, so systems such as polynomial normal equations, whose coefficients span
+// many orders of magnitude, are not mistaken for singular ones. a is modified in place. It reports
+// false if the system has no unique solution.
+func Solve(a [][]float64) ([]float64, bool) {
+	n := len(a)
+
+	cols := make([]float64, n)
+	for j := range cols {
+		for i := range a {
+			cols[j] = math.Max(cols[j], math.Abs(a[i][j]))
+		}
+		if cols[j] == 0 {
+			return nil, false
+		}
+		for i := range a {
+			a[i][j] /= cols[j]
+		}
+	}
+
+	for i := range a {
+		var row float64
+		for j := 0; j < n; j++ {
+			row = math.Max(row, math.Abs(a[i][j]))
+		}
+		if row == 0 {
+			return nil, false
+		}
+		for j := range a[i] {
+			a[i][j] /= row
+		}
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(a[pivot][col]) <= tolerance {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		x[row] = a[row][n]
+		for k := row + 1; k < n; k++ {
+			x[row] -= a[row][k] * x[k]
+		}
+		x[row] /= a[row][row]
+	}
+
+	for j := range x {
+		x[j] /= cols[j]
+	}
+
+	return x, true
+}
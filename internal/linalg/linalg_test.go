@@ -0,0 +1,64 @@
+package linalg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	// x + 2y = 5 and 3x + 4y = 6
+	x, ok := Solve([][]float64{
+		{1, 2, 5},
+		{3, 4, 6},
+	})
+	if !ok {
+		t.Fatalf("got %v want %v", ok, true)
+	}
+
+	want := []float64{-4, 4.5}
+	for i := range want {
+		if math.Abs(x[i]-want[i]) > 1e-12 {
+			t.Errorf("got %v want %v", x, want)
+		}
+	}
+}
+
+func TestSolvePivot(t *testing.T) {
+	x, ok := Solve([][]float64{
+		{0, 1, 3},
+		{2, 0, 4},
+	})
+	if !ok {
+		t.Fatalf("got %v want %v", ok, true)
+	}
+	if x[0] != 2 || x[1] != 3 {
+		t.Errorf("got %v want %v", x, []float64{2, 3})
+	}
+}
+
+func TestSolveSingular(t *testing.T) {
+	cases := [][][]float64{
+		{{1, 2, 3}, {2, 4, 6}},
+		{{0, 0, 1}, {0, 0, 1}},
+		{{1e6, 1e6 * (1 + 1e-15), 1}, {1e6, 1e6, 1}},
+	}
+
+	for _, a := range cases {
+		if x, ok := Solve(a); ok {
+			t.Errorf("got %v, %v want nil, false", x, ok)
+		}
+	}
+}
+
+func TestSolveScaled(t *testing.T) {
+	x, ok := Solve([][]float64{
+		{1e-20, 0, 1e-20},
+		{0, 1e20, 2e20},
+	})
+	if !ok {
+		t.Fatalf("got %v want %v", ok, true)
+	}
+	if x[0] != 1 || x[1] != 2 {
+		t.Errorf("got %v want %v", x, []float64{1, 2})
+	}
+}
@@ -0,0 +1,81 @@
+package scale
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter. The verbs are
+//
+//	%v, %s  the temperature as by String, such as 21.5 °C or 21.5 ± 0.1 °C
+//	%q      the same, quoted
+//	%e, %E, %f, %F, %g, %G  the temperature and uncertainty formatted with the verb
+//	%#v     a Go syntax representation of the scale
+//
+// The precision is passed on to the numeric verbs, and is the number of decimal places for %v,
+// %s and %q, so %.2v gives 21.50 °C. The + flag writes the scale name instead of the unit symbol,
+// so %+v gives 21.5 celsius. The width pads the whole temperature, to the left with the - flag.
+func (b Scale) Format(f fmt.State, verb rune) {
+	var format byte
+	switch verb {
+	case 'v', 's', 'q':
+		format = 'g'
+	case 'e', 'E', 'f', 'g', 'G':
+		format = byte(verb)
+	case 'F':
+		format = 'f'
+	default:
+		fmt.Fprintf(f, "%%!%c(scale.Scale=%s)", verb, b.String())
+		return
+	}
+
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, b.goString())
+		return
+	}
+
+	prec, ok := f.Precision()
+	switch {
+	case ok && format == 'g' && verb != 'g':
+		format = 'f'
+	case !ok && (format == 'g' || format == 'G'):
+		prec = -1
+	case !ok:
+		prec = 6 // default of fmt
+	}
+
+	unit := b.Unit
+	if f.Flag('+') {
+		unit = b.Name
+	}
+
+	s := strconv.FormatFloat(b.temp, format, prec, 64)
+	if b.uncertainty != 0 {
+		s += " ± " + strconv.FormatFloat(b.uncertainty, format, prec, 64)
+	}
+	s += " " + unit
+
+	if verb == 'q' {
+		s = strconv.Quote(s)
+	}
+
+	if width, ok := f.Width(); ok && width > len([]rune(s)) {
+		padding := strings.Repeat(" ", width-len([]rune(s)))
+		if f.Flag('-') {
+			s += padding
+		} else {
+			s = padding + s
+		}
+	}
+
+	io.WriteString(f, s)
+}
+
+// goString returns a Go syntax representation of the scale, with the temperature and uncertainty
+// in place of their unexported fields.
+func (b Scale) goString() string {
+	return fmt.Sprintf("scale.Scale{Type:%d, Name:%q, Alias:%q, Unit:%q, Temp:%#v, Uncertainty:%#v, Policy:%#v}",
+		b.Type, b.Name, b.Alias, b.Unit, b.temp, b.uncertainty, b.Policy)
+}
@@ -0,0 +1,52 @@
+package scale
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	s := NewCelsius()
+	s.SetTemp(21.5)
+
+	u := NewCelsius()
+	u.SetTemp(21.5)
+	u.SetUncertainty(0.1)
+
+	cases := []struct {
+		format string
+		scale  any
+		want   string
+	}{
+		{"%v", s, "21.5 °C"},
+		{"%v", *s, "21.5 °C"},
+		{"%s", s, "21.5 °C"},
+		{"%.2v", s, "21.50 °C"},
+		{"%.0v", s, "22 °C"},
+		{"%+v", s, "21.5 celsius"},
+		{"%+.1v", s, "21.5 celsius"},
+		{"%q", s, `"21.5 °C"`},
+		{"%.2f", s, "21.50 °C"},
+		{"%F", s, "21.500000 °C"},
+		{"%e", s, "2.150000e+01 °C"},
+		{"%.3g", s, "21.5 °C"},
+		{"%10v", s, "   21.5 °C"},
+		{"%-10v|", s, "21.5 °C   |"},
+		{"%4v", s, "21.5 °C"},
+		{"%v", u, "21.5 ± 0.1 °C"},
+		{"%.2v", u, "21.50 ± 0.10 °C"},
+		{"%+v", u, "21.5 ± 0.1 celsius"},
+		{"%d", s, "%!d(scale.Scale=21.5 °C)"},
+		{"%#v", s, `scale.Scale{Type:1, Name:"celsius", Alias:"", Unit:"°C", Temp:21.5, Uncertainty:0, ` +
+			`Policy:scale.Policy{NonFinite:0, AbsoluteZero:0, Max:0, AboveMax:0}}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			got := fmt.Sprintf(c.format, c.scale)
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package scale
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer. The temperature is logged as a group with the value, the
+// uncertainty if it is not zero, the unit and the temperature in kelvin, so temperatures in
+// different scales can be compared in structured logs.
+func (b Scale) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.Float64("value", b.temp)}
+	if b.uncertainty != 0 {
+		attrs = append(attrs, slog.Float64("uncertainty", b.uncertainty))
+	}
+	attrs = append(attrs, slog.String("unit", b.Unit))
+
+	if _, ok := b.absoluteZero(); ok {
+		attrs = append(attrs, slog.Float64("kelvin", b.toKelvin(b.temp)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,38 @@
+package scale
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	s := NewCelsius()
+	s.SetTemp(21.5)
+
+	u := NewFahrenheit()
+	u.SetTemp(32)
+	u.SetUncertainty(0.5)
+
+	cases := []struct {
+		scale *Scale
+		want  string
+	}{
+		{s, "temp.value=21.5 temp.unit=°C temp.kelvin=294.65\n"},
+		{u, "temp.value=32 temp.uncertainty=0.5 temp.unit=°F temp.kelvin=273.15\n"},
+		{&Scale{Type: 99, Unit: "?"}, "temp.value=0 temp.unit=?\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.scale.Unit, func(t *testing.T) {
+			w := new(bytes.Buffer)
+			logger := slog.New(slog.NewTextHandler(w, nil))
+			logger.Info("reading", "temp", c.scale)
+
+			if !strings.HasSuffix(w.String(), " msg=reading "+c.want) {
+				t.Errorf("got %v want suffix %v", w.String(), c.want)
+			}
+		})
+	}
+}
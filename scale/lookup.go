@@ -0,0 +1,238 @@
+package scale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownScale is an error type for names which do not identify a scale.
+var ErrUnknownScale = fmt.Errorf("unknown temperature scale")
+
+// ErrAmbiguousScale is an error type for names which identify more than one scale.
+var ErrAmbiguousScale = fmt.Errorf("ambiguous temperature scale")
+
+// foldReplacer removes diacritics from the letters used in scale names and common misspellings.
+var foldReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"æ", "ae", "œ", "oe", "ß", "ss",
+)
+
+// symbolReplacer replaces Unicode compatibility characters and look-alikes of the degree sign.
+var symbolReplacer = strings.NewReplacer(
+	"℃", "°C", "℉", "°F", "K", "K",
+	"º", "°", "˚", "°",
+)
+
+// degreePrefixes are the designators which may precede a unit symbol, longest first.
+var degreePrefixes = []string{"degrees", "degree", "deg", "°"}
+
+// Lookup returns a new scale identified by name. The name is resolved in order of precedence as
+//
+//   - a name or alias of a scale, compared case and diacritic insensitively
+//   - a unit symbol such as K, °C, ℃, degF or deg R, where a bare symbol must start with an
+//     upper case letter, so R is Rankine while r is an abbreviation
+//   - an abbreviation of a name or alias which uniquely identifies a scale
+//
+// If no scale matches, the error suggests the closest names.
+func Lookup(name string) (*Scale, error) {
+	folded := fold(name)
+	if folded == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScale, name)
+	}
+
+	// A degree designator can only be followed by a symbol or a name, such as degC or degrees celsius
+	symbol := strings.TrimSpace(symbolReplacer.Replace(name))
+	if rest, ok := cutDegree(symbol); ok {
+		if s := lookupSymbol(rest, false); s != nil {
+			return s, nil
+		} else if s := lookupName(rest); s != nil {
+			return s, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScale, name)
+	}
+
+	if s := lookupName(name); s != nil {
+		return s, nil
+	} else if s := lookupSymbol(symbol, true); s != nil {
+		return s, nil
+	}
+
+	var matches []*Scale
+	for _, s := range All() {
+		for _, n := range names(s) {
+			if strings.HasPrefix(fold(n), folded) {
+				matches = append(matches, s)
+				break
+			}
+		}
+	}
+
+	if len(matches) == 1 {
+		return matches[0], nil
+	} else if len(matches) > 1 {
+		return nil, fmt.Errorf("%w: %s, matches: %s", ErrAmbiguousScale, name, strings.Join(scaleNames(matches), ", "))
+	}
+
+	suggestions := Suggest(name)
+	if len(suggestions) > 0 {
+		return nil, fmt.Errorf("%w: %s, did you mean: %s", ErrUnknownScale, name, strings.Join(suggestions, ", "))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownScale, name)
+}
+
+// lookupName returns the scale with the name or alias name, or nil.
+func lookupName(name string) *Scale {
+	for _, s := range All() {
+		for _, n := range names(s) {
+			if fold(n) == fold(name) {
+				return s
+			}
+		}
+	}
+
+	return nil
+}
+
+// lookupSymbol returns the scale with the unit symbol symbol, with or without its degree sign, or
+// nil. If upper is true, symbol must start with an upper case letter like the unit symbols do.
+func lookupSymbol(symbol string, upper bool) *Scale {
+	bare := strings.TrimPrefix(symbol, "°")
+	if bare == "" || upper && strings.ToUpper(bare[:1]) != bare[:1] {
+		return nil
+	}
+
+	for _, s := range All() {
+		if fold(strings.TrimPrefix(s.Unit, "°")) == fold(bare) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// cutDegree returns s without a leading degree designator, such as °, deg or degrees, and any
+// separator following it. It reports whether s had a designator followed by something else.
+func cutDegree(s string) (string, bool) {
+	for _, prefix := range degreePrefixes {
+		if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			rest := strings.TrimLeft(s[len(prefix):], " _.-")
+			return rest, rest != ""
+		}
+	}
+
+	return s, false
+}
+
+// Suggest returns the names of the scales closest to name, with the closest first. A name is
+// close when its edit distance, or the edit distance of its abbreviation to the length of name,
+// is at most a third of the length of name.
+func Suggest(name string) []string {
+	folded := []rune(fold(name))
+	limit := len(folded) / 3
+
+	type suggestion struct {
+		name     string
+		distance int
+	}
+
+	var suggestions []suggestion
+	for _, s := range All() {
+		best := limit + 1
+		for _, n := range names(s) {
+			candidate := []rune(fold(n))
+			if d := distance(folded, candidate); d < best {
+				best = d
+			}
+
+			if len(candidate) > len(folded) {
+				if d := distance(folded, candidate[:len(folded)]); d < best {
+					best = d
+				}
+			}
+		}
+
+		if best <= limit {
+			suggestions = append(suggestions, suggestion{s.Name, best})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	result := []string{}
+	for _, s := range suggestions {
+		result = append(result, s.name)
+	}
+
+	return result
+}
+
+// fold returns s in lower case without diacritics and surrounding whitespace.
+func fold(s string) string {
+	return foldReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// names returns the name and alias of s.
+func names(s *Scale) []string {
+	if s.Alias != "" {
+		return []string{s.Name, s.Alias}
+	}
+
+	return []string{s.Name}
+}
+
+func scaleNames(scales []*Scale) (names []string) {
+	for _, s := range scales {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+// distance returns the optimal string alignment distance between a and b, which counts
+// insertions, deletions, substitutions and transpositions of adjacent runes as one edit.
+func distance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,179 @@
+package scale
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		name string
+		want int
+	}{
+		{"kelvin", KELVIN},
+		{"Kelvin", KELVIN},
+		{" celsius ", CELSIUS},
+		{"c", CELSIUS},
+		{"fahr", FAHRENHEIT},
+		{"ra", RANKINE},
+		{"d", DELISLE},
+		{"n", NEWTON},
+		{"réaumur", REAUMUR},
+		{"reaumur", REAUMUR},
+		{"RÉAUMUR", REAUMUR},
+		{"ré", REAUMUR},
+		{"re", REAUMUR},
+		{"rømer", ROMER},
+		{"romer", ROMER},
+		{"RØ", ROMER},
+		{"rö", ROMER},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := Lookup(c.name)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if s.Type != c.want {
+				t.Errorf("got %v want %v", s.Type, c.want)
+			}
+		})
+	}
+}
+
+func TestLookupSymbol(t *testing.T) {
+	cases := []struct {
+		name string
+		want int
+	}{
+		{"K", KELVIN},
+		{"K", KELVIN},
+		{"°K", KELVIN},
+		{"°C", CELSIUS},
+		{"℃", CELSIUS},
+		{"C", CELSIUS},
+		{"degC", CELSIUS},
+		{"deg C", CELSIUS},
+		{"deg_C", CELSIUS},
+		{"DEG C", CELSIUS},
+		{"º C", CELSIUS},
+		{"degrees celsius", CELSIUS},
+		{"°F", FAHRENHEIT},
+		{"℉", FAHRENHEIT},
+		{"DegF", FAHRENHEIT},
+		{"degree F", FAHRENHEIT},
+		{"°R", RANKINE},
+		{"R", RANKINE},
+		{"deg R", RANKINE},
+		{"°r", RANKINE},
+		{"°De", DELISLE},
+		{"De", DELISLE},
+		{"°N", NEWTON},
+		{"N", NEWTON},
+		{"°Ré", REAUMUR},
+		{"Ré", REAUMUR},
+		{"Re", REAUMUR},
+		{"°Rø", ROMER},
+		{"Rø", ROMER},
+		{"Ro", ROMER},
+		{"deg Rø", ROMER},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, err := Lookup(c.name)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if s.Type != c.want {
+				t.Errorf("got %v want %v", s.Type, c.want)
+			}
+		})
+	}
+}
+
+func TestLookupError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		msg  string
+	}{
+		{"", ErrUnknownScale, "unknown temperature scale: "},
+		{"x", ErrUnknownScale, "unknown temperature scale: x"},
+		{"wedgwood", ErrUnknownScale, "unknown temperature scale: wedgwood"},
+		{"r", ErrAmbiguousScale, "ambiguous temperature scale: r, matches: rankine, réaumur, rømer"},
+		{"degX", ErrUnknownScale, "unknown temperature scale: degX"},
+		{"°", ErrUnknownScale, "unknown temperature scale: °"},
+		{"reamur", ErrUnknownScale, "unknown temperature scale: reamur, did you mean: réaumur"},
+		{"romr", ErrUnknownScale, "unknown temperature scale: romr, did you mean: rømer"},
+		{"farenheit", ErrUnknownScale, "unknown temperature scale: farenheit, did you mean: fahrenheit"},
+		{"kelivn", ErrUnknownScale, "unknown temperature scale: kelivn, did you mean: kelvin"},
+		{"celcius", ErrUnknownScale, "unknown temperature scale: celcius, did you mean: celsius"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Lookup(c.name)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("got %v want %v", err, c.err)
+			}
+
+			if err.Error() != c.msg {
+				t.Errorf("got %v want %v", err.Error(), c.msg)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{"x", []string{}},
+		{"xyz", []string{}},
+		{"kelivn", []string{"kelvin"}},
+		{"farh", []string{"fahrenheit"}},
+		{"reamur", []string{"réaumur"}},
+		{"reamer", []string{"réaumur", "rømer"}},
+		{"newtn", []string{"newton"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Suggest(c.name)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kelvin", "kelvin", 0},
+		{"kelivn", "kelvin", 1},
+		{"farenheit", "fahrenheit", 1},
+		{"romr", "romer", 1},
+		{"celcius", "celsius", 1},
+		{"abc", "cab", 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.a+"/"+c.b, func(t *testing.T) {
+			got := distance([]rune(c.a), []rune(c.b))
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
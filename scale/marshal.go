@@ -0,0 +1,162 @@
+package scale
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTemperature is an error type for text which is not a temperature with a scale.
+var ErrInvalidTemperature = fmt.Errorf("invalid temperature")
+
+// jsonObject is the object form of a temperature in JSON.
+type jsonObject struct {
+	Value       *float64 `json:"value"`
+	Uncertainty float64  `json:"uncertainty,omitempty"`
+	Scale       string   `json:"scale"`
+}
+
+// MarshalText returns the temperature in the same form as String, such as 21.5 °C or
+// 21.5 ± 0.1 °C. Since YAML encoders use encoding.TextMarshaler, this is also the YAML form.
+// The unit is taken from the type of the scale, so a zero Scale is 0 K.
+func (b Scale) MarshalText() ([]byte, error) {
+	c, err := b.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText sets the scale and temperature from text such as 21.5 °C, 21.5 ± 0.1 °C or
+// -40 fahrenheit. The scale is resolved with Lookup and the temperature is validated with SetTemp
+// using the policy of b.
+func (b *Scale) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	temp, rest, ok := cutNumber(s)
+	if !ok {
+		return fmt.Errorf("tempconv: %w: %q", ErrInvalidTemperature, s)
+	}
+
+	var uncertainty float64
+	if u, found := cutUncertainty(rest); found {
+		uncertainty, rest, ok = cutNumber(u)
+		if !ok {
+			return fmt.Errorf("tempconv: %w: %q", ErrInvalidTemperature, s)
+		}
+	}
+
+	if strings.TrimSpace(rest) == "" {
+		return fmt.Errorf("tempconv: %w: %q, missing scale", ErrInvalidTemperature, s)
+	}
+
+	return b.set(strings.TrimSpace(rest), temp, uncertainty)
+}
+
+// MarshalJSON returns the temperature as a JSON string in the form of MarshalText. Use
+// MarshalObject for the object form.
+func (b Scale) MarshalJSON() ([]byte, error) {
+	text, err := b.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// MarshalObject returns the temperature as a JSON object with the fields value, scale and, if
+// not zero, uncertainty, such as {"value":21.5,"scale":"celsius"}.
+func (b Scale) MarshalObject() ([]byte, error) {
+	c, err := b.canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonObject{Value: &c.temp, Uncertainty: c.uncertainty, Scale: c.Name})
+}
+
+// UnmarshalJSON sets the scale and temperature from either a JSON string in the form of
+// UnmarshalText or a JSON object with the fields value, scale and optionally uncertainty.
+func (b *Scale) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("tempconv: %w: %v", ErrInvalidTemperature, err)
+		}
+		return b.UnmarshalText([]byte(text))
+	}
+
+	var obj jsonObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("tempconv: %w: %v", ErrInvalidTemperature, err)
+	} else if obj.Value == nil {
+		return fmt.Errorf("tempconv: %w: missing value", ErrInvalidTemperature)
+	} else if obj.Scale == "" {
+		return fmt.Errorf("tempconv: %w: missing scale", ErrInvalidTemperature)
+	}
+
+	return b.set(obj.Scale, *obj.Value, obj.Uncertainty)
+}
+
+// canonical returns a new scale of the type of b with the temperature and uncertainty of b. Its
+// name and unit are those of the type, even if the Name and Unit of b are unset or changed.
+func (b Scale) canonical() (*Scale, error) {
+	for _, s := range All() {
+		if s.Type == b.Type {
+			s.temp, s.uncertainty = b.temp, b.uncertainty
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("tempconv: %w: type %d", ErrUnknownScale, b.Type)
+}
+
+// set replaces b with the scale identified by name, keeping the policy of b, and sets the
+// temperature and uncertainty. b is left unchanged on error.
+func (b *Scale) set(name string, temp, uncertainty float64) error {
+	s, err := Lookup(name)
+	if err != nil {
+		return fmt.Errorf("tempconv: %w", err)
+	}
+
+	s.Policy = b.Policy
+	if err := s.SetTemp(temp); err != nil {
+		return err
+	}
+
+	if err := s.SetUncertainty(uncertainty); err != nil {
+		return err
+	}
+
+	*b = *s
+	return nil
+}
+
+// cutNumber returns the number at the start of s and the rest of s. The longest prefix which is a
+// number is used, so both 21.5 °C and 21.5°C are read as 21.5.
+func cutNumber(s string) (float64, string, bool) {
+	for i := len(s); i > 0; i-- {
+		if v, err := strconv.ParseFloat(s[:i], 64); err == nil {
+			return v, s[i:], true
+		}
+	}
+
+	return 0, s, false
+}
+
+// cutUncertainty returns s without a leading uncertainty sign, ± or +-, and reports whether s
+// had one.
+func cutUncertainty(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	for _, sign := range []string{"±", "+-"} {
+		if rest, ok := strings.CutPrefix(s, sign); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+
+	return s, false
+}
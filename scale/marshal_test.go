@@ -0,0 +1,281 @@
+package scale
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	cases := []struct {
+		scale       *Scale
+		temp        float64
+		uncertainty float64
+		want        string
+	}{
+		{NewCelsius(), 21.5, 0, "21.5 °C"},
+		{NewKelvin(), 0, 0, "0 K"},
+		{NewFahrenheit(), -40, 0, "-40 °F"},
+		{NewCelsius(), 21.5, 0.1, "21.5 ± 0.1 °C"},
+		{NewReaumur(), 10, 0, "10 °Ré"},
+		{&Scale{}, 0, 0, "0 K"},
+		{&Scale{Type: CELSIUS, Unit: "C"}, 21.5, 0, "21.5 °C"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.want, func(t *testing.T) {
+			c.scale.SetTemp(c.temp)
+			c.scale.SetUncertainty(c.uncertainty)
+
+			got, err := c.scale.MarshalText()
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if string(got) != c.want {
+				t.Errorf("got %v want %v", string(got), c.want)
+			}
+		})
+	}
+}
+
+func TestMarshalTextError(t *testing.T) {
+	_, err := Scale{Type: 99}.MarshalText()
+	if !errors.Is(err, ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, ErrUnknownScale)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	cases := []struct {
+		text        string
+		name        string
+		temp        float64
+		uncertainty float64
+	}{
+		{"21.5 °C", "celsius", 21.5, 0},
+		{"21.5°C", "celsius", 21.5, 0},
+		{"  0 K  ", "kelvin", 0, 0},
+		{"-40 fahrenheit", "fahrenheit", -40, 0},
+		{"1e2 degC", "celsius", 100, 0},
+		{"21.5 ± 0.1 °C", "celsius", 21.5, 0.1},
+		{"21.5+-0.1 celsius", "celsius", 21.5, 0.1},
+		{"10 °Ré", "réaumur", 10, 0},
+		{"10 deg R", "rankine", 10, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.text, func(t *testing.T) {
+			var s Scale
+			err := s.UnmarshalText([]byte(c.text))
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if s.Name != c.name {
+				t.Errorf("got %v want %v", s.Name, c.name)
+			}
+			if s.Temp() != c.temp {
+				t.Errorf("got %v want %v", s.Temp(), c.temp)
+			}
+			if s.Uncertainty() != c.uncertainty {
+				t.Errorf("got %v want %v", s.Uncertainty(), c.uncertainty)
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	cases := []struct {
+		text string
+		err  error
+	}{
+		{"", ErrInvalidTemperature},
+		{"°C", ErrInvalidTemperature},
+		{"21.5", ErrInvalidTemperature},
+		{"21.5 ±", ErrInvalidTemperature},
+		{"21.5 ± °C", ErrInvalidTemperature},
+		{"21.5 foo", ErrUnknownScale},
+		{"-300 °C", ErrAbsoluteZero},
+		{"NaN K", ErrNonFinite},
+		{"21.5 ± -0.1 °C", ErrNegativeUncertainty},
+	}
+
+	for _, c := range cases {
+		t.Run(c.text, func(t *testing.T) {
+			s := NewKelvin()
+			err := s.UnmarshalText([]byte(c.text))
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+			if s.Name != "kelvin" || s.Temp() != 0 {
+				t.Errorf("got %v want %v", s, NewKelvin())
+			}
+		})
+	}
+}
+
+func TestUnmarshalTextPolicy(t *testing.T) {
+	s := &Scale{Policy: Policy{AbsoluteZero: Allow}}
+	err := s.UnmarshalText([]byte("-300 °C"))
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if s.Temp() != -300 {
+		t.Errorf("got %v want %v", s.Temp(), -300)
+	}
+	if s.Policy.AbsoluteZero != Allow {
+		t.Errorf("got %v want %v", s.Policy.AbsoluteZero, Allow)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	s := NewCelsius()
+	s.SetTemp(21.5)
+
+	got, err := json.Marshal(struct {
+		Value *Scale `json:"value"`
+		Plain Scale  `json:"plain"`
+	}{s, *s})
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	want := `{"value":"21.5 °C","plain":"21.5 °C"}`
+	if string(got) != want {
+		t.Errorf("got %v want %v", string(got), want)
+	}
+}
+
+func TestMarshalObject(t *testing.T) {
+	cases := []struct {
+		scale       *Scale
+		temp        float64
+		uncertainty float64
+		want        string
+	}{
+		{NewCelsius(), 21.5, 0, `{"value":21.5,"scale":"celsius"}`},
+		{NewCelsius(), 21.5, 0.1, `{"value":21.5,"uncertainty":0.1,"scale":"celsius"}`},
+		{NewRomer(), 0, 0, `{"value":0,"scale":"rømer"}`},
+		{&Scale{}, 0, 0, `{"value":0,"scale":"kelvin"}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.want, func(t *testing.T) {
+			c.scale.SetTemp(c.temp)
+			c.scale.SetUncertainty(c.uncertainty)
+
+			got, err := c.scale.MarshalObject()
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if string(got) != c.want {
+				t.Errorf("got %v want %v", string(got), c.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		data        string
+		name        string
+		temp        float64
+		uncertainty float64
+	}{
+		{`"21.5 °C"`, "celsius", 21.5, 0},
+		{`{"value":21.5,"scale":"celsius"}`, "celsius", 21.5, 0},
+		{`{"value":21.5,"uncertainty":0.1,"scale":"°C"}`, "celsius", 21.5, 0.1},
+		{` {"scale":"K","value":0} `, "kelvin", 0, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.data, func(t *testing.T) {
+			var s Scale
+			err := json.Unmarshal([]byte(c.data), &s)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if s.Name != c.name {
+				t.Errorf("got %v want %v", s.Name, c.name)
+			}
+			if s.Temp() != c.temp {
+				t.Errorf("got %v want %v", s.Temp(), c.temp)
+			}
+			if s.Uncertainty() != c.uncertainty {
+				t.Errorf("got %v want %v", s.Uncertainty(), c.uncertainty)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	cases := []struct {
+		data string
+		err  error
+	}{
+		{`{"scale":"celsius"}`, ErrInvalidTemperature},
+		{`{"value":21.5}`, ErrInvalidTemperature},
+		{`{"value":"21.5","scale":"celsius"}`, ErrInvalidTemperature},
+		{`21.5`, ErrInvalidTemperature},
+		{`{"value":21.5,"scale":"foo"}`, ErrUnknownScale},
+		{`{"value":-300,"scale":"celsius"}`, ErrAbsoluteZero},
+		{`"-300 °C"`, ErrAbsoluteZero},
+	}
+
+	for _, c := range cases {
+		t.Run(c.data, func(t *testing.T) {
+			var s Scale
+			err := json.Unmarshal([]byte(c.data), &s)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, s := range All() {
+		t.Run(s.Name, func(t *testing.T) {
+			s.SetTemp(s.AbsoluteZero() + 1)
+			s.SetUncertainty(0.25)
+			if s.Type == DELISLE {
+				s.SetTemp(s.AbsoluteZero() - 1)
+			}
+
+			for _, marshal := range []func() ([]byte, error){s.MarshalJSON, s.MarshalObject} {
+				data, err := marshal()
+				if err != nil {
+					t.Fatalf("got %v want nil", err)
+				}
+
+				var got Scale
+				err = json.Unmarshal(data, &got)
+				if err != nil {
+					t.Fatalf("got %v want nil", err)
+				}
+				if got != *s {
+					t.Errorf("got %v want %v from %s", got, *s, data)
+				}
+			}
+		})
+	}
+
+	// A zero Scale is kelvin, even though its name and unit are unset
+	t.Run("zero", func(t *testing.T) {
+		var zero Scale
+		for _, marshal := range []func() ([]byte, error){zero.MarshalJSON, zero.MarshalObject} {
+			data, err := marshal()
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			var got Scale
+			err = json.Unmarshal(data, &got)
+			if err != nil {
+				t.Fatalf("got %v want nil from %s", err, data)
+			}
+			if got != *NewKelvin() {
+				t.Errorf("got %v want %v from %s", got, *NewKelvin(), data)
+			}
+		}
+	})
+}
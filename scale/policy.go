@@ -0,0 +1,172 @@
+package scale
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Action is what a Policy does with a temperature which violates it.
+type Action int
+
+const (
+	Reject Action = iota // Return an error
+	Allow                // Accept the temperature as it is
+	Clamp                // Clamp the temperature to the bound it violates
+)
+
+// ErrImplausible is an error type for temperatures above the plausibility bound of a Policy.
+var ErrImplausible = fmt.Errorf("temperature above plausibility bound")
+
+// ErrUnknownAction is an error type for names which do not identify an Action.
+var ErrUnknownAction = fmt.Errorf("unknown policy action")
+
+// Policy is the validation policy of a scale, applied by SetTemp. The zero value rejects
+// non-finite temperatures and temperatures below absolute zero, and has no upper bound.
+type Policy struct {
+	// NonFinite is the action for NaN and infinite temperatures. Clamp clamps -Inf to absolute
+	// zero and +Inf to Max, and rejects NaN and +Inf without a Max.
+	NonFinite Action
+
+	// AbsoluteZero is the action for temperatures below absolute zero. Allow is meant for
+	// negative absolute temperatures, which are then also allowed by the convert package.
+	AbsoluteZero Action
+
+	// Max is the upper plausibility bound in kelvin, or 0 for none.
+	Max float64
+
+	// AboveMax is the action for temperatures above Max.
+	AboveMax Action
+}
+
+func (a Action) String() string {
+	switch a {
+	case Reject:
+		return "reject"
+	case Allow:
+		return "allow"
+	case Clamp:
+		return "clamp"
+	}
+
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// ParseAction returns the action called name, where error is a synonym of reject.
+func ParseAction(name string) (Action, error) {
+	switch strings.ToLower(name) {
+	case "reject", "error":
+		return Reject, nil
+	case "allow":
+		return Allow, nil
+	case "clamp":
+		return Clamp, nil
+	}
+
+	return Reject, fmt.Errorf("tempconv: %w: %s", ErrUnknownAction, name)
+}
+
+// kelvinScale is the linear conversion of a scale type to kelvin: a temperature t is
+// (t-t0)*num/den + k0 kelvin, where t0 in the scale and k0 in kelvin are the same reference
+// temperature. The conversions multiply before they divide, so whole degrees convert exactly.
+type kelvinScale struct {
+	t0, k0, num, den float64
+}
+
+// kelvinScales holds the conversion of each scale type to kelvin.
+var kelvinScales = map[int]kelvinScale{
+	KELVIN:     {0, 0, 1, 1},
+	CELSIUS:    {0, 273.15, 1, 1},
+	FAHRENHEIT: {-459.67, 0, 5, 9},
+	RANKINE:    {0, 0, 5, 9},
+	DELISLE:    {0, 373.15, -2, 3},
+	NEWTON:     {0, 273.15, 100, 33},
+	REAUMUR:    {0, 273.15, 5, 4},
+	ROMER:      {7.5, 273.15, 40, 21},
+}
+
+// ToKelvin returns t, a temperature in the scale, in kelvin together with the size of a degree of
+// the scale in kelvin, which is negative for the inverted Delisle scale.
+func (b *Scale) ToKelvin(t float64) (k, slope float64, err error) {
+	c, ok := kelvinScales[b.Type]
+	if !ok {
+		return math.NaN(), math.NaN(), fmt.Errorf("tempconv: %w: type %d", ErrUnknownScale, b.Type)
+	}
+
+	return (t*c.num - c.t0*c.num + c.k0*c.den) / c.den, c.num / c.den, nil
+}
+
+// FromKelvin returns k in kelvin as a temperature in the scale together with the size of a kelvin
+// in degrees of the scale, which is negative for the inverted Delisle scale.
+func (b *Scale) FromKelvin(k float64) (t, slope float64, err error) {
+	c, ok := kelvinScales[b.Type]
+	if !ok {
+		return math.NaN(), math.NaN(), fmt.Errorf("tempconv: %w: type %d", ErrUnknownScale, b.Type)
+	}
+
+	if c.num < 0 {
+		// Divide by a positive number, so the reference temperature converts to +0.
+		return (c.k0*c.den - k*c.den - c.t0*c.num) / -c.num, c.den / c.num, nil
+	}
+
+	return (k*c.den - c.k0*c.den + c.t0*c.num) / c.num, c.den / c.num, nil
+}
+
+// toKelvin returns t in the scale as kelvin, or NaN if the scale type is unknown.
+func (b *Scale) toKelvin(t float64) float64 {
+	k, _, _ := b.ToKelvin(t)
+	return k
+}
+
+// fromKelvin returns k in kelvin as a temperature in the scale, or NaN if the scale type is unknown.
+func (b *Scale) fromKelvin(k float64) float64 {
+	t, _, _ := b.FromKelvin(k)
+	return t
+}
+
+// applyPolicy returns t after applying the policy of the scale, whose absolute zero is zero.
+func (b *Scale) applyPolicy(t, zero float64) (float64, error) {
+	p := b.Policy
+
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		switch {
+		case p.NonFinite == Allow:
+			return t, nil
+		case p.NonFinite == Clamp && b.toKelvin(t) < 0:
+			return zero, nil
+		case p.NonFinite == Clamp && b.toKelvin(t) > 0 && p.Max > 0:
+			return b.fromKelvin(p.Max), nil
+		}
+		return 0, fmt.Errorf("tempconv: %w: %g", ErrNonFinite, t)
+	}
+
+	sign := 1.0
+	if b.Type == DELISLE {
+		sign = -1 // Delisle scale is inverted
+	}
+
+	v, err := checkAbsoluteZero(sign*t, sign*zero)
+	if err != nil {
+		switch p.AbsoluteZero {
+		case Allow:
+			return t, nil
+		case Clamp:
+			return zero, nil
+		}
+		return 0, fmt.Errorf("tempconv: %w: %g %s, absolute zero is %g %s", ErrAbsoluteZero, t, b.Unit, zero, b.Unit)
+	}
+	t = sign * v
+
+	if p.Max > 0 && b.toKelvin(t) > p.Max {
+		bound := b.fromKelvin(p.Max)
+		switch p.AboveMax {
+		case Allow:
+			return t, nil
+		case Clamp:
+			return bound, nil
+		}
+		return 0, fmt.Errorf("tempconv: %w: %g %s, bound is %g %s", ErrImplausible, t, b.Unit, bound, b.Unit)
+	}
+
+	return t, nil
+}
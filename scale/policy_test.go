@@ -0,0 +1,149 @@
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPolicy(t *testing.T) {
+	cases := []struct {
+		scale  *Scale
+		policy Policy
+		temp   float64
+		want   float64
+	}{
+		{NewCelsius(), Policy{NonFinite: Allow}, math.Inf(1), math.Inf(1)},
+		{NewCelsius(), Policy{NonFinite: Allow}, math.Inf(-1), math.Inf(-1)},
+		{NewCelsius(), Policy{NonFinite: Clamp}, math.Inf(-1), absoluteZeroC},
+		{NewCelsius(), Policy{NonFinite: Clamp, Max: 373.15}, math.Inf(1), 100},
+		{NewDelisle(), Policy{NonFinite: Clamp}, math.Inf(1), absoluteZeroDe},
+		{NewDelisle(), Policy{NonFinite: Clamp, Max: 373.15}, math.Inf(-1), 0},
+		{NewCelsius(), Policy{AbsoluteZero: Allow}, -300, -300},
+		{NewCelsius(), Policy{AbsoluteZero: Clamp}, -300, absoluteZeroC},
+		{NewKelvin(), Policy{AbsoluteZero: Allow}, -1, -1},
+		{NewDelisle(), Policy{AbsoluteZero: Clamp}, 600, absoluteZeroDe},
+		{NewCelsius(), Policy{Max: 373.15}, 100, 100},
+		{NewCelsius(), Policy{Max: 373.15, AboveMax: Clamp}, 150, 100},
+		{NewCelsius(), Policy{Max: 373.15, AboveMax: Allow}, 150, 150},
+		{NewFahrenheit(), Policy{Max: 373.15, AboveMax: Clamp}, 300, 212},
+		{NewDelisle(), Policy{Max: 373.15, AboveMax: Clamp}, -10, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v %+v %g", c.scale.Name, c.policy, c.temp), func(t *testing.T) {
+			c.scale.Policy = c.policy
+			err := c.scale.SetTemp(c.temp)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			got := c.scale.Temp()
+			if !(got == c.want || math.Abs(got-c.want) < 1e-9) {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPolicyError(t *testing.T) {
+	cases := []struct {
+		scale  *Scale
+		policy Policy
+		temp   float64
+		err    error
+	}{
+		{NewCelsius(), Policy{}, math.NaN(), ErrNonFinite},
+		{NewCelsius(), Policy{NonFinite: Clamp}, math.NaN(), ErrNonFinite},
+		{NewCelsius(), Policy{NonFinite: Clamp}, math.Inf(1), ErrNonFinite},
+		{NewCelsius(), Policy{}, -300, ErrAbsoluteZero},
+		{NewCelsius(), Policy{Max: 373.15}, 150, ErrImplausible},
+		{NewDelisle(), Policy{Max: 373.15}, -10, ErrImplausible},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v %+v %g", c.scale.Name, c.policy, c.temp), func(t *testing.T) {
+			c.scale.Policy = c.policy
+			err := c.scale.SetTemp(c.temp)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestPolicyUncertainty(t *testing.T) {
+	s := NewCelsius()
+	s.Policy.NonFinite = Allow
+	err := s.SetUncertainty(math.Inf(1))
+	if err != nil {
+		t.Errorf("got %v want %v", err, nil)
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	cases := []struct {
+		name string
+		want Action
+	}{
+		{"reject", Reject},
+		{"error", Reject},
+		{"allow", Allow},
+		{"Clamp", Clamp},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ParseAction(c.name)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+
+	_, err := ParseAction("ignore")
+	if !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("got %v want %v", err, ErrUnknownAction)
+	}
+}
+
+func TestActionString(t *testing.T) {
+	for _, a := range []Action{Reject, Allow, Clamp} {
+		got, err := ParseAction(a.String())
+		if err != nil || got != a {
+			t.Errorf("got %v, %v want %v", got, err, a)
+		}
+	}
+}
+
+func TestKelvinSlope(t *testing.T) {
+	for _, s := range All() {
+		t.Run(s.Name, func(t *testing.T) {
+			if got := s.toKelvin(s.AbsoluteZero()); math.Abs(got) > 1e-9 {
+				t.Errorf("got %v want %v", got, 0)
+			}
+
+			if got := s.fromKelvin(s.toKelvin(42)); math.Abs(got-42) > 1e-9 {
+				t.Errorf("got %v want %v", got, 42)
+			}
+
+			_, m, _ := s.ToKelvin(0)
+			if _, got, _ := s.FromKelvin(0); math.Abs(got*m-1) > 1e-12 {
+				t.Errorf("got %v want %v", got, 1/m)
+			}
+		})
+	}
+
+	s := &Scale{Type: -1}
+	if _, _, err := s.ToKelvin(0); !errors.Is(err, ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, ErrUnknownScale)
+	}
+	if _, _, err := s.FromKelvin(0); !errors.Is(err, ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, ErrUnknownScale)
+	}
+}
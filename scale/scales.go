@@ -32,6 +32,12 @@ const (
 // AbsoluteZeroError is an error type for temperatures below absolute zero.
 var ErrAbsoluteZero = fmt.Errorf("temperature below absolute zero")
 
+// ErrNegativeUncertainty is an error type for negative standard uncertainties.
+var ErrNegativeUncertainty = fmt.Errorf("uncertainty must not be negative")
+
+// ErrNonFinite is an error type for temperatures and uncertainties which are NaN or infinite.
+var ErrNonFinite = fmt.Errorf("value must be finite")
+
 // NewKelvin returns a new Kelvin scale.
 func NewKelvin() *Scale {
 	return &Scale{Type: KELVIN, Name: "kelvin", Unit: "K"}
@@ -73,46 +79,90 @@ func NewRomer() *Scale {
 }
 
 type Scale struct {
-	Type  int
-	Name  string
-	Alias string
-	temp  float64
-	Unit  string
+	Type        int
+	Name        string
+	Alias       string
+	temp        float64
+	uncertainty float64
+	Unit        string
+	Policy      Policy
+}
+
+func (b Scale) String() string {
+	if b.uncertainty != 0 {
+		return fmt.Sprintf("%g ± %g %v", b.temp, b.uncertainty, b.Unit)
+	}
+	return fmt.Sprintf("%g %v", b.temp, b.Unit)
 }
 
-func (b Scale) String() string { return fmt.Sprintf("%g %v", b.temp, b.Unit) }
 func (b *Scale) Temp() float64 { return b.temp }
-func (b *Scale) SetTemp(t float64) (err error) {
+
+// SetTemp sets the temperature after validating it with the policy of the scale.
+func (b *Scale) SetTemp(t float64) error {
+	zero, ok := b.absoluteZero()
+	if !ok {
+		return fmt.Errorf("tempconv: %w: type %d", ErrUnknownScale, b.Type)
+	}
+
+	t, err := b.applyPolicy(t, zero)
+	if err != nil {
+		return err
+	}
+
+	b.temp = t
+	return nil
+}
+
+// AbsoluteZero returns absolute zero in the scale, or NaN if the scale type is unknown.
+func (b *Scale) AbsoluteZero() float64 {
+	zero, ok := b.absoluteZero()
+	if !ok {
+		return math.NaN()
+	}
+
+	return zero
+}
+
+func (b *Scale) absoluteZero() (float64, bool) {
 	switch b.Type {
 	case KELVIN:
-		t, err = checkAbsoluteZero(t, absoluteZeroK)
+		return absoluteZeroK, true
 	case CELSIUS:
-		t, err = checkAbsoluteZero(t, absoluteZeroC)
+		return absoluteZeroC, true
 	case FAHRENHEIT:
-		t, err = checkAbsoluteZero(t, absoluteZeroF)
+		return absoluteZeroF, true
 	case RANKINE:
-		t, err = checkAbsoluteZero(t, absoluteZeroR)
+		return absoluteZeroR, true
 	case DELISLE:
-		t, err = checkAbsoluteZero(-t, -absoluteZeroDe)
-		t = -t // Delisle scale is inverted
+		return absoluteZeroDe, true
 	case NEWTON:
-		t, err = checkAbsoluteZero(t, absoluteZeroN)
+		return absoluteZeroN, true
 	case REAUMUR:
-		t, err = checkAbsoluteZero(t, absoluteZeroRé)
+		return absoluteZeroRé, true
 	case ROMER:
-		t, err = checkAbsoluteZero(t, absolutezeroRø)
+		return absolutezeroRø, true
 	}
 
-	if err != nil {
-		return err
+	return 0, false
+}
+
+func (b *Scale) Uncertainty() float64 { return b.uncertainty }
+
+// SetUncertainty sets the standard uncertainty of the temperature.
+func (b *Scale) SetUncertainty(u float64) error {
+	if (math.IsNaN(u) || math.IsInf(u, 0)) && b.Policy.NonFinite != Allow {
+		return fmt.Errorf("tempconv: %w: %g", ErrNonFinite, u)
+	} else if u < 0 {
+		return fmt.Errorf("tempconv: %w", ErrNegativeUncertainty)
 	}
 
-	b.temp = t
+	b.uncertainty = u
 	return nil
 }
 
-func ScaleNames() (names [][]string) {
-	scales := []*Scale{
+// All returns a new scale of every supported type, in the order of their types.
+func All() []*Scale {
+	return []*Scale{
 		NewKelvin(),
 		NewCelsius(),
 		NewFahrenheit(),
@@ -122,8 +172,10 @@ func ScaleNames() (names [][]string) {
 		NewReaumur(),
 		NewRomer(),
 	}
+}
 
-	for _, s := range scales {
+func ScaleNames() (names [][]string) {
+	for _, s := range All() {
 		if !(s.Alias == "") {
 			names = append(names, []string{s.Name, s.Alias})
 		} else {
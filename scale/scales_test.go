@@ -3,6 +3,7 @@ package scale
 import (
 	"errors"
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -42,6 +43,19 @@ func TestFactory(t *testing.T) {
 	}
 }
 
+func TestAll(t *testing.T) {
+	scales := All()
+	if len(scales) != ROMER+1 {
+		t.Fatalf("got %v scales want %v", len(scales), ROMER+1)
+	}
+
+	for i, s := range scales {
+		if s.Type != i {
+			t.Errorf("got %v want %v", s.Type, i)
+		}
+	}
+}
+
 func TestString(t *testing.T) {
 	cases := []struct {
 		scale *Scale
@@ -68,6 +82,19 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestStringUncertainty(t *testing.T) {
+	s := NewCelsius()
+	s.SetTemp(20)
+	s.SetUncertainty(0.1)
+
+	got := fmt.Sprint(s)
+	want := "20 ± 0.1 °C"
+
+	if got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
 func TestName(t *testing.T) {
 	cases := []struct {
 		scale *Scale
@@ -152,6 +179,28 @@ func TestSetTemp(t *testing.T) {
 	}
 }
 
+func TestSetUncertainty(t *testing.T) {
+	s := NewCelsius()
+
+	err := s.SetUncertainty(0.5)
+	if err != nil {
+		t.Errorf("got %v want nil", err)
+	}
+
+	if s.Uncertainty() != 0.5 {
+		t.Errorf("got %v want %v", s.Uncertainty(), 0.5)
+	}
+
+	err = s.SetUncertainty(-0.5)
+	if !errors.Is(err, ErrNegativeUncertainty) {
+		t.Errorf("got %v want %v", err, ErrNegativeUncertainty)
+	}
+
+	if s.Uncertainty() != 0.5 {
+		t.Errorf("got %v want %v", s.Uncertainty(), 0.5)
+	}
+}
+
 func TestUnit(t *testing.T) {
 	cases := []struct {
 		tempscale *Scale
@@ -204,3 +253,59 @@ func TestAbsoluteZeroError(t *testing.T) {
 		})
 	}
 }
+
+func TestSetTempError(t *testing.T) {
+	cases := []struct {
+		name  string
+		scale *Scale
+		temp  float64
+		err   error
+		msg   string
+	}{
+		{"nan", NewCelsius(), math.NaN(), ErrNonFinite, "tempconv: value must be finite: NaN"},
+		{"inf", NewCelsius(), math.Inf(1), ErrNonFinite, "tempconv: value must be finite: +Inf"},
+		{"-inf", NewCelsius(), math.Inf(-1), ErrNonFinite, "tempconv: value must be finite: -Inf"},
+		{"unknown", &Scale{Type: 99}, 0, ErrUnknownScale, "tempconv: unknown temperature scale: type 99"},
+		{"absolute zero", NewCelsius(), -300, ErrAbsoluteZero,
+			"tempconv: temperature below absolute zero: -300 °C, absolute zero is -273.15 °C"},
+		{"absolute zero delisle", NewDelisle(), 600, ErrAbsoluteZero,
+			"tempconv: temperature below absolute zero: 600 °De, absolute zero is 559.725 °De"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.scale.SetTemp(c.temp)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("got %v want %v", err, c.err)
+			}
+
+			if err.Error() != c.msg {
+				t.Errorf("got %v want %v", err.Error(), c.msg)
+			}
+		})
+	}
+}
+
+func TestSetUncertaintyNonFinite(t *testing.T) {
+	for _, u := range []float64{math.NaN(), math.Inf(1)} {
+		err := NewCelsius().SetUncertainty(u)
+		if !errors.Is(err, ErrNonFinite) {
+			t.Errorf("got %v want %v", err, ErrNonFinite)
+		}
+	}
+}
+
+func TestAbsoluteZero(t *testing.T) {
+	for _, s := range All() {
+		t.Run(s.Name, func(t *testing.T) {
+			err := s.SetTemp(s.AbsoluteZero())
+			if err != nil {
+				t.Errorf("got %v want %v", err, nil)
+			}
+		})
+	}
+
+	if !math.IsNaN((&Scale{Type: 99}).AbsoluteZero()) {
+		t.Errorf("got %v want %v", (&Scale{Type: 99}).AbsoluteZero(), math.NaN())
+	}
+}
@@ -0,0 +1,139 @@
+// Package temperature provides temperatures whose scale is part of their type, so mixing up
+// scales is a compile time error. A Temp[Celsius] can only be compared with or subtracted from
+// another Temp[Celsius], and is converted to other scales with To:
+//
+//	body, _ := temperature.New[temperature.Celsius](37)
+//	fever := body.Add(1.5)
+//	f, _ := temperature.To[temperature.Fahrenheit](fever) // 101.3 °F
+//
+// Conversions use the convert package, and the scale package is used for validation and for
+// interoperating with code using scale.Scale.
+package temperature
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+// Scale is the constraint satisfied by the scale types. The scale types are empty types which
+// are only used as type arguments of Temp.
+type Scale interface {
+	Kelvin | Celsius | Fahrenheit | Rankine | Delisle | Newton | Reaumur | Romer
+	newScale() *scale.Scale
+}
+
+type (
+	Kelvin     struct{}
+	Celsius    struct{}
+	Fahrenheit struct{}
+	Rankine    struct{}
+	Delisle    struct{}
+	Newton     struct{}
+	Reaumur    struct{}
+	Romer      struct{}
+)
+
+func (Kelvin) newScale() *scale.Scale     { return scale.NewKelvin() }
+func (Celsius) newScale() *scale.Scale    { return scale.NewCelsius() }
+func (Fahrenheit) newScale() *scale.Scale { return scale.NewFahrenheit() }
+func (Rankine) newScale() *scale.Scale    { return scale.NewRankine() }
+func (Delisle) newScale() *scale.Scale    { return scale.NewDelisle() }
+func (Newton) newScale() *scale.Scale     { return scale.NewNewton() }
+func (Reaumur) newScale() *scale.Scale    { return scale.NewReaumur() }
+func (Romer) newScale() *scale.Scale      { return scale.NewRomer() }
+
+// Temp is a temperature in the scale S. Its value is only reachable through its methods, so a
+// temperature cannot be relabelled as another scale or multiplied by a temperature. Temperatures of
+// the same scale compare with ==. The zero Temp is zero degrees in the scale S. A Temp is not
+// validated unless it is created with New or From, or checked with Validate.
+type Temp[S Scale] struct {
+	v float64
+}
+
+// New returns v as a temperature in the scale S, validated like scale.Scale.SetTemp.
+func New[S Scale](v float64) (Temp[S], error) {
+	t := Temp[S]{v}
+	return t, t.Validate()
+}
+
+// From returns the temperature of s in the scale S, converting it if s is in another scale.
+func From[S Scale](s *scale.Scale) (Temp[S], error) {
+	var zero S
+	out := zero.newScale()
+	if s != nil {
+		out.Policy = s.Policy
+	}
+
+	if err := convert.Convert(s, out); err != nil {
+		return Temp[S]{math.NaN()}, err
+	}
+
+	return Temp[S]{out.Temp()}, nil
+}
+
+// To returns t converted to the scale T. The conversion is not validated, so temperatures below
+// absolute zero and non-finite temperatures are converted like any other.
+func To[T, S Scale](t Temp[S]) (Temp[T], error) {
+	in := t.unchecked()
+
+	var zero T
+	out := zero.newScale()
+	out.Policy = in.Policy
+
+	if err := convert.Convert(in, out); err != nil {
+		return Temp[T]{math.NaN()}, err
+	}
+
+	return Temp[T]{out.Temp()}, nil
+}
+
+// Float64 returns the temperature in degrees of the scale S.
+func (t Temp[S]) Float64() float64 { return t.v }
+
+// Add returns t raised by d degrees of the scale S.
+func (t Temp[S]) Add(d float64) Temp[S] { return Temp[S]{t.v + d} }
+
+// Sub returns t lowered by d degrees of the scale S.
+func (t Temp[S]) Sub(d float64) Temp[S] { return Temp[S]{t.v - d} }
+
+// Diff returns the difference t - u in degrees of the scale S.
+func (t Temp[S]) Diff(u Temp[S]) float64 { return t.v - u.v }
+
+// Validate returns an error if t is below absolute zero or not finite.
+func (t Temp[S]) Validate() error {
+	_, err := t.Scale()
+	return err
+}
+
+// Scale returns t as a new scale.Scale, validated like scale.Scale.SetTemp.
+func (t Temp[S]) Scale() (*scale.Scale, error) {
+	var zero S
+	s := zero.newScale()
+	if err := s.SetTemp(t.v); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Unit returns the unit symbol of the scale S, such as °C.
+func (t Temp[S]) Unit() string {
+	var zero S
+	return zero.newScale().Unit
+}
+
+func (t Temp[S]) String() string {
+	return strconv.FormatFloat(t.v, 'g', -1, 64) + " " + t.Unit()
+}
+
+// unchecked returns t as a new scale.Scale with a policy which allows any temperature.
+func (t Temp[S]) unchecked() *scale.Scale {
+	var zero S
+	s := zero.newScale()
+	s.Policy = scale.Policy{NonFinite: scale.Allow, AbsoluteZero: scale.Allow}
+	s.SetTemp(t.v)
+	return s
+}
@@ -0,0 +1,175 @@
+package temperature
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+func TestTo(t *testing.T) {
+	cases := []struct {
+		name string
+		to   func() (float64, error)
+		want float64
+	}{
+		{"celsius to fahrenheit", func() (float64, error) { got, err := To[Fahrenheit](Temp[Celsius]{100}); return got.Float64(), err }, 212},
+		{"celsius to kelvin", func() (float64, error) { got, err := To[Kelvin](Temp[Celsius]{-273.15}); return got.Float64(), err }, 0},
+		{"kelvin to delisle", func() (float64, error) { got, err := To[Delisle](Temp[Kelvin]{373.15}); return got.Float64(), err }, 0},
+		{"rankine to celsius", func() (float64, error) { got, err := To[Celsius](Temp[Rankine]{491.67}); return got.Float64(), err }, 0},
+		{"newton to romer", func() (float64, error) { got, err := To[Romer](Temp[Newton]{33}); return got.Float64(), err }, 60},
+		{"celsius to reaumur", func() (float64, error) { got, err := To[Reaumur](Temp[Celsius]{100}); return got.Float64(), err }, 80},
+		{"below absolute zero", func() (float64, error) { got, err := To[Kelvin](Temp[Celsius]{-300}); return got.Float64(), err }, -26.85},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.to()
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if math.Abs(got-c.want) > 1e-9 {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestToNonFinite(t *testing.T) {
+	got, err := To[Kelvin](Temp[Celsius]{math.Inf(1)})
+	if err != nil || !math.IsInf(got.Float64(), 1) {
+		t.Errorf("got %v, %v want %v", got, err, math.Inf(1))
+	}
+
+	got, err = To[Kelvin](Temp[Celsius]{math.NaN()})
+	if err != nil || !math.IsNaN(got.Float64()) {
+		t.Errorf("got %v, %v want %v", got, err, math.NaN())
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	body, err := New[Celsius](37)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	fever := body.Add(1.5)
+
+	if fever.Float64() != 38.5 {
+		t.Errorf("got %v want %v", fever, 38.5)
+	}
+	if fever.Sub(1.5) != body {
+		t.Errorf("got %v want %v", fever.Sub(1.5), body)
+	}
+	if d := fever.Diff(body); d != 1.5 {
+		t.Errorf("got %v want %v", d, 1.5)
+	}
+
+	f, err := To[Fahrenheit](body)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	got, err := To[Celsius](f)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if d := got.Diff(body); math.Abs(d) > 1e-9 {
+		t.Errorf("got %v want %v", d, 0)
+	}
+}
+
+func TestNew(t *testing.T) {
+	got, err := New[Kelvin](300)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if got.Float64() != 300 {
+		t.Errorf("got %v want %v", got, 300)
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		new  func() error
+	}{
+		{"celsius", scale.ErrAbsoluteZero, func() error { _, err := New[Celsius](-300); return err }},
+		{"delisle", scale.ErrAbsoluteZero, func() error { _, err := New[Delisle](600); return err }},
+		{"nan", scale.ErrNonFinite, func() error { _, err := New[Kelvin](math.NaN()); return err }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.new(); !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestFrom(t *testing.T) {
+	s := scale.NewFahrenheit()
+	s.SetTemp(212)
+
+	got, err := From[Celsius](s)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if math.Abs(got.Float64()-100) > 1e-9 {
+		t.Errorf("got %v want %v", got, 100)
+	}
+
+	_, err = From[Celsius](nil)
+	if !errors.Is(err, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, scale.ErrUnknownScale)
+	}
+
+	_, err = From[Celsius](&scale.Scale{Type: 99})
+	if !errors.Is(err, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, scale.ErrUnknownScale)
+	}
+}
+
+func TestScale(t *testing.T) {
+	s, err := Temp[Reaumur]{10}.Scale()
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if s.Name != "réaumur" || s.Temp() != 10 {
+		t.Errorf("got %v want %v", s, "10 °Ré")
+	}
+
+	_, err = Temp[Kelvin]{-1}.Scale()
+	if !errors.Is(err, scale.ErrAbsoluteZero) {
+		t.Errorf("got %v want %v", err, scale.ErrAbsoluteZero)
+	}
+
+	if err := (Temp[Kelvin]{-1}).Validate(); !errors.Is(err, scale.ErrAbsoluteZero) {
+		t.Errorf("got %v want %v", err, scale.ErrAbsoluteZero)
+	}
+}
+
+func TestString(t *testing.T) {
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{Temp[Kelvin]{0}.String(), "0 K"},
+		{Temp[Celsius]{21.5}.String(), "21.5 °C"},
+		{Temp[Fahrenheit]{-40}.String(), "-40 °F"},
+		{Temp[Rankine]{1}.String(), "1 °R"},
+		{Temp[Delisle]{1}.String(), "1 °De"},
+		{Temp[Newton]{1}.String(), "1 °N"},
+		{Temp[Reaumur]{1}.String(), "1 °Ré"},
+		{Temp[Romer]{1}.String(), "1 °Rø"},
+	}
+
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("got %v want %v", c.got, c.want)
+		}
+	}
+
+	if u := (Temp[Celsius]{}).Unit(); u != "°C" {
+		t.Errorf("got %v want %v", u, "°C")
+	}
+}
@@ -0,0 +1,64 @@
+// Package tempflag provides temperature command line flags, such as -max-temp 80F, for tools built
+// with the flag package or spf13/pflag. Temperatures are parsed like on the tempconv command line,
+// as a number followed by a scale name, abbreviation or unit symbol.
+package tempflag
+
+import (
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+// Value is a temperature flag value. It implements flag.Value, and with its Type method the Value
+// interface of spf13/pflag.
+type Value struct {
+	temp *scale.Scale
+	to   *scale.Scale
+}
+
+// New returns a new unset temperature flag value. If to is not nil, temperatures are converted to
+// the scale of to and validated with its policy; to itself is not modified.
+func New(to *scale.Scale) *Value {
+	return &Value{to: to}
+}
+
+// Set parses s as a temperature such as 80F, 80 °F, -40 celsius or 21.5±0.1 C.
+func (v *Value) Set(s string) error {
+	temp := new(scale.Scale)
+	if v.to != nil {
+		temp.Policy = v.to.Policy
+	}
+
+	if err := temp.UnmarshalText([]byte(s)); err != nil {
+		return err
+	}
+
+	if v.to != nil {
+		out := *v.to
+		if err := convert.Convert(temp, &out); err != nil {
+			return err
+		}
+		temp = &out
+	}
+
+	v.temp = temp
+	return nil
+}
+
+// String returns the temperature, or an empty string if it is not set.
+func (v *Value) String() string {
+	if v == nil || v.temp == nil {
+		return ""
+	}
+
+	return v.temp.String()
+}
+
+// Type returns the name of the value type for spf13/pflag usage messages.
+func (v *Value) Type() string {
+	return "temperature"
+}
+
+// Scale returns the temperature, or nil if it is not set.
+func (v *Value) Scale() *scale.Scale {
+	return v.temp
+}
@@ -0,0 +1,141 @@
+package tempflag
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+// pflagValue is the Value interface of spf13/pflag.
+type pflagValue interface {
+	String() string
+	Set(string) error
+	Type() string
+}
+
+var (
+	_ flag.Value = (*Value)(nil)
+	_ pflagValue = (*Value)(nil)
+)
+
+func TestSet(t *testing.T) {
+	cases := []struct {
+		arg  string
+		name string
+		temp float64
+	}{
+		{"80F", "fahrenheit", 80},
+		{"80 °F", "fahrenheit", 80},
+		{"-40 celsius", "celsius", -40},
+		{"-40c", "celsius", -40},
+		{"300K", "kelvin", 300},
+		{"10 deg R", "rankine", 10},
+		{"10 ré", "réaumur", 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.arg, func(t *testing.T) {
+			v := New(nil)
+			err := v.Set(c.arg)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+			if v.Scale().Name != c.name || v.Scale().Temp() != c.temp {
+				t.Errorf("got %v want %v %v", v.Scale(), c.temp, c.name)
+			}
+		})
+	}
+}
+
+func TestSetNormalize(t *testing.T) {
+	to := scale.NewCelsius()
+	v := New(to)
+	err := v.Set("212F")
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	if v.Scale().Name != "celsius" || math.Abs(v.Scale().Temp()-100) > 1e-9 {
+		t.Errorf("got %v want %v", v.Scale(), "100 °C")
+	}
+	if to.Temp() != 0 {
+		t.Errorf("got %v want %v", to.Temp(), 0)
+	}
+}
+
+func TestSetError(t *testing.T) {
+	cases := []struct {
+		arg string
+		to  *scale.Scale
+		err error
+	}{
+		{"80", nil, scale.ErrInvalidTemperature},
+		{"hot", nil, scale.ErrInvalidTemperature},
+		{"80 foo", nil, scale.ErrUnknownScale},
+		{"80 r", nil, scale.ErrAmbiguousScale},
+		{"-300 C", nil, scale.ErrAbsoluteZero},
+		{"1000 K", &scale.Scale{Type: scale.CELSIUS, Name: "celsius", Unit: "°C", Policy: scale.Policy{Max: 500}}, scale.ErrImplausible},
+	}
+
+	for _, c := range cases {
+		t.Run(c.arg, func(t *testing.T) {
+			v := New(c.to)
+			err := v.Set(c.arg)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+			if v.Scale() != nil {
+				t.Errorf("got %v want nil", v.Scale())
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	v := New(nil)
+	if v.String() != "" {
+		t.Errorf("got %q want %q", v.String(), "")
+	}
+
+	v.Set("80F")
+	if v.String() != "80 °F" {
+		t.Errorf("got %q want %q", v.String(), "80 °F")
+	}
+
+	if v.Type() != "temperature" {
+		t.Errorf("got %q want %q", v.Type(), "temperature")
+	}
+}
+
+func TestFlagSet(t *testing.T) {
+	w := new(bytes.Buffer)
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(w)
+
+	maxTemp := New(scale.NewKelvin())
+	maxTemp.Set("80C")
+	flags.Var(maxTemp, "max-temp", "Maximum temperature")
+
+	err := flags.Parse([]string{"-max-temp", "100C"})
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if maxTemp.Scale().Temp() != 373.15 {
+		t.Errorf("got %v want %v", maxTemp.Scale().Temp(), 373.15)
+	}
+
+	err = flags.Parse([]string{"-max-temp", "100 foo"})
+	if err == nil {
+		t.Fatalf("got %v want error", err)
+	}
+
+	want := `invalid value "100 foo" for flag -max-temp: tempconv: unknown temperature scale: foo`
+	if !strings.HasPrefix(w.String(), want) {
+		t.Errorf("got %v want prefix %v", w.String(), want)
+	}
+}
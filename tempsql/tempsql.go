@@ -0,0 +1,153 @@
+// Package tempsql stores temperatures in SQL databases. Kelvin stores a temperature as a
+// normalized float in kelvin and Text stores it as text with its unit, such as 21.5 °C. Both
+// implement sql.Scanner and driver.Valuer, and convert a scanned temperature into the scale given
+// by the caller, so the form of each column can be chosen independently of the scales used in code.
+package tempsql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+// ErrUnsupportedType is an error type for column values which cannot hold a temperature.
+var ErrUnsupportedType = errors.New("unsupported type for temperature column")
+
+// Kelvin is a temperature stored as a float in kelvin. The uncertainty of the temperature is not
+// stored.
+//
+// A nil Scale is stored as NULL. When scanning, the temperature is converted to Scale, or to a
+// new kelvin scale if Scale is nil, and Valid reports whether the column was not NULL.
+type Kelvin struct {
+	Scale *scale.Scale
+	Valid bool
+}
+
+// Value returns the temperature in kelvin, or nil if Scale is nil.
+func (k Kelvin) Value() (driver.Value, error) {
+	if k.Scale == nil {
+		return nil, nil
+	}
+
+	kelvin := scale.NewKelvin()
+	kelvin.Policy = k.Scale.Policy
+	if err := convert.Convert(k.Scale, kelvin); err != nil {
+		return nil, err
+	}
+
+	return kelvin.Temp(), nil
+}
+
+// Scan reads a temperature in kelvin from a float, integer or numeric text column.
+func (k *Kelvin) Scan(src any) error {
+	var v float64
+	var err error
+
+	switch src := src.(type) {
+	case nil:
+		k.Valid = false
+		return nil
+	case float64:
+		v = src
+	case int64:
+		v = float64(src)
+	case []byte:
+		v, err = strconv.ParseFloat(strings.TrimSpace(string(src)), 64)
+	case string:
+		v, err = strconv.ParseFloat(strings.TrimSpace(src), 64)
+	default:
+		return fmt.Errorf("tempconv: %w: %T", ErrUnsupportedType, src)
+	}
+
+	if err != nil {
+		return fmt.Errorf("tempconv: %w: %q", scale.ErrInvalidTemperature, src)
+	}
+
+	kelvin := scale.NewKelvin()
+	if k.Scale != nil {
+		kelvin.Policy = k.Scale.Policy
+	}
+
+	if err := kelvin.SetTemp(v); err != nil {
+		return err
+	}
+
+	if err := into(&k.Scale, kelvin); err != nil {
+		return err
+	}
+
+	k.Valid = true
+	return nil
+}
+
+// Text is a temperature stored as text with its unit and uncertainty, in the form of
+// scale.Scale.MarshalText, such as 21.5 °C or 21.5 ± 0.1 °C.
+//
+// A nil Scale is stored as NULL. When scanning, the temperature is converted to Scale, or kept in
+// the scale it was stored in if Scale is nil, and Valid reports whether the column was not NULL.
+type Text struct {
+	Scale *scale.Scale
+	Valid bool
+}
+
+// Value returns the temperature as text, or nil if Scale is nil.
+func (t Text) Value() (driver.Value, error) {
+	if t.Scale == nil {
+		return nil, nil
+	}
+
+	text, err := t.Scale.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+// Scan reads a temperature from a text column.
+func (t *Text) Scan(src any) error {
+	var text []byte
+
+	switch src := src.(type) {
+	case nil:
+		t.Valid = false
+		return nil
+	case []byte:
+		text = src
+	case string:
+		text = []byte(src)
+	default:
+		return fmt.Errorf("tempconv: %w: %T", ErrUnsupportedType, src)
+	}
+
+	stored := new(scale.Scale)
+	if t.Scale != nil {
+		stored.Policy = t.Scale.Policy
+	}
+
+	if err := stored.UnmarshalText(text); err != nil {
+		return err
+	}
+
+	if err := into(&t.Scale, stored); err != nil {
+		return err
+	}
+
+	t.Valid = true
+	return nil
+}
+
+// into converts src to the scale dst points to, or sets dst to src if it is nil.
+func into(dst **scale.Scale, src *scale.Scale) error {
+	if *dst == nil {
+		*dst = src
+		return nil
+	}
+
+	return convert.Convert(src, *dst)
+}
@@ -0,0 +1,247 @@
+package tempsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+// fakeDriver is an in-memory driver with a single row. Exec replaces the row with its arguments and
+// Query returns the row.
+type fakeDriver struct {
+	mu  sync.Mutex
+	row []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeStmt struct{ d *fakeDriver }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.row = append([]driver.Value(nil), args...)
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &fakeRows{row: s.d.row}, nil
+}
+
+type fakeRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (r *fakeRows) Columns() []string {
+	columns := make([]string, len(r.row))
+	for i := range columns {
+		columns[i] = "c"
+	}
+	return columns
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.row)
+	return nil
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("tempsql-fake", fake)
+}
+
+func openFake(t *testing.T) *sql.DB {
+	db, err := sql.Open("tempsql-fake", "")
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRoundTrip(t *testing.T) {
+	db := openFake(t)
+
+	in := scale.NewCelsius()
+	in.SetTemp(21.5)
+	in.SetUncertainty(0.1)
+
+	_, err := db.Exec("INSERT", Kelvin{Scale: in}, Text{Scale: in})
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	if fake.row[0] != 294.65 {
+		t.Errorf("got %v want %v", fake.row[0], 294.65)
+	}
+	if fake.row[1] != "21.5 ± 0.1 °C" {
+		t.Errorf("got %v want %v", fake.row[1], "21.5 ± 0.1 °C")
+	}
+
+	k := Kelvin{Scale: scale.NewFahrenheit()}
+	var text Text
+	err = db.QueryRow("SELECT").Scan(&k, &text)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	if !k.Valid || k.Scale.Name != "fahrenheit" || math.Abs(k.Scale.Temp()-70.7) > 1e-9 {
+		t.Errorf("got %v want %v", k.Scale, "70.7 °F")
+	}
+	if k.Scale.Uncertainty() != 0 {
+		t.Errorf("got %v want %v", k.Scale.Uncertainty(), 0)
+	}
+	if !text.Valid || *text.Scale != *in {
+		t.Errorf("got %v want %v", text.Scale, in)
+	}
+}
+
+func TestNull(t *testing.T) {
+	db := openFake(t)
+
+	_, err := db.Exec("INSERT", Kelvin{}, Text{})
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	if fake.row[0] != nil || fake.row[1] != nil {
+		t.Errorf("got %v want %v", fake.row, []driver.Value{nil, nil})
+	}
+
+	k := Kelvin{Valid: true}
+	text := Text{Valid: true}
+	err = db.QueryRow("SELECT").Scan(&k, &text)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if k.Valid || text.Valid {
+		t.Errorf("got %v, %v want false, false", k.Valid, text.Valid)
+	}
+}
+
+func TestKelvinScan(t *testing.T) {
+	cases := []struct {
+		src  any
+		want float64
+	}{
+		{273.15, 273.15},
+		{int64(300), 300},
+		{[]byte("273.15"), 273.15},
+		{" 0 ", 0},
+	}
+
+	for _, c := range cases {
+		var k Kelvin
+		err := k.Scan(c.src)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		if k.Scale.Name != "kelvin" || k.Scale.Temp() != c.want {
+			t.Errorf("got %v want %v K", k.Scale, c.want)
+		}
+	}
+}
+
+func TestTextScan(t *testing.T) {
+	var text Text
+	err := text.Scan([]byte("-40 °F"))
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if text.Scale.Name != "fahrenheit" || text.Scale.Temp() != -40 {
+		t.Errorf("got %v want %v", text.Scale, "-40 °F")
+	}
+
+	text = Text{Scale: scale.NewCelsius()}
+	err = text.Scan("-40 °F")
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if text.Scale.Name != "celsius" || math.Abs(text.Scale.Temp()+40) > 1e-9 {
+		t.Errorf("got %v want %v", text.Scale, "-40 °C")
+	}
+}
+
+func TestScanError(t *testing.T) {
+	cases := []struct {
+		name    string
+		scanner sql.Scanner
+		src     any
+		err     error
+	}{
+		{"kelvin type", &Kelvin{}, true, ErrUnsupportedType},
+		{"kelvin text", &Kelvin{}, "warm", scale.ErrInvalidTemperature},
+		{"kelvin absolute zero", &Kelvin{}, -1.0, scale.ErrAbsoluteZero},
+		{"kelvin non-finite", &Kelvin{}, math.NaN(), scale.ErrNonFinite},
+		{"text type", &Text{}, 1.0, ErrUnsupportedType},
+		{"text scale", &Text{}, "21.5 foo", scale.ErrUnknownScale},
+		{"text absolute zero", &Text{}, "-300 °C", scale.ErrAbsoluteZero},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.scanner.Scan(c.src)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestScanPolicy(t *testing.T) {
+	k := Kelvin{Scale: &scale.Scale{Type: scale.CELSIUS, Name: "celsius", Unit: "°C", Policy: scale.Policy{AbsoluteZero: scale.Clamp}}}
+	err := k.Scan(-1.0)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if k.Scale.Temp() != -273.15 {
+		t.Errorf("got %v want %v", k.Scale.Temp(), -273.15)
+	}
+}
+
+func TestTextValueZero(t *testing.T) {
+	got, err := Text{Scale: &scale.Scale{}}.Value()
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+	if got != "0 K" {
+		t.Errorf("got %v want %v", got, "0 K")
+	}
+}
+
+func TestValueError(t *testing.T) {
+	_, err := Kelvin{Scale: &scale.Scale{Type: 99}}.Value()
+	if !errors.Is(err, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, scale.ErrUnknownScale)
+	}
+
+	_, err = Text{Scale: &scale.Scale{Type: 99}}.Value()
+	if !errors.Is(err, scale.ErrUnknownScale) {
+		t.Errorf("got %v want %v", err, scale.ErrUnknownScale)
+	}
+}
@@ -0,0 +1,182 @@
+package thermistor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/internal/linalg"
+	"github.com/solbero/tempconv/scale"
+)
+
+var (
+	ErrInvalidResistance = errors.New("resistance must be positive")
+	ErrTooFewPoints      = errors.New("at least three calibration points required")
+	ErrSingular          = errors.New("calibration points do not determine coefficients")
+	ErrInvalidModel      = errors.New("thermistor model coefficients do not determine a resistance")
+	ErrOutOfRange        = errors.New("temperature outside range of thermistor model")
+)
+
+// Model is a thermistor model relating resistance to temperature.
+type Model interface {
+	Temperature(r float64, s *scale.Scale) error
+	Resistance(s *scale.Scale) (float64, error)
+}
+
+// SteinhartHart is the Steinhart–Hart model 1/T = A + B ln(R) + C ln(R)³, with T in kelvin.
+type SteinhartHart struct {
+	A, B, C float64
+}
+
+// Temperature sets the temperature of s from the resistance r in ohms.
+func (m SteinhartHart) Temperature(r float64, s *scale.Scale) error {
+	if r <= 0 {
+		return fmt.Errorf("tempconv: %w", ErrInvalidResistance)
+	}
+
+	l := math.Log(r)
+	return fromKelvin(1/(m.A+m.B*l+m.C*l*l*l), s)
+}
+
+// Resistance returns the resistance in ohms at the temperature of s. It returns an error if the
+// model has no positive, finite resistance at that temperature.
+func (m SteinhartHart) Resistance(s *scale.Scale) (float64, error) {
+	if m.B == 0 && m.C == 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrInvalidModel)
+	}
+
+	t, err := toKelvin(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if m.C == 0 {
+		return resistance(math.Exp((1/t - m.A) / m.B))
+	}
+
+	x := (m.A - 1/t) / (2 * m.C)
+	d := math.Pow(m.B/(3*m.C), 3) + x*x
+	if d < 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrOutOfRange)
+	}
+
+	y := math.Sqrt(d)
+	return resistance(math.Exp(math.Cbrt(y-x) - math.Cbrt(y+x)))
+}
+
+// Beta is the Beta model 1/T = 1/T0 + ln(R/R0)/β, where R0 is the resistance at T0.
+type Beta struct {
+	R0   float64
+	T0   *scale.Scale
+	Beta float64
+}
+
+// Temperature sets the temperature of s from the resistance r in ohms.
+func (m Beta) Temperature(r float64, s *scale.Scale) error {
+	if r <= 0 || m.R0 <= 0 {
+		return fmt.Errorf("tempconv: %w", ErrInvalidResistance)
+	}
+
+	t0, err := toKelvin(m.T0)
+	if err != nil {
+		return err
+	}
+
+	return fromKelvin(1/(1/t0+math.Log(r/m.R0)/m.Beta), s)
+}
+
+// Resistance returns the resistance in ohms at the temperature of s. It returns an error if the
+// model has no positive, finite resistance at that temperature.
+func (m Beta) Resistance(s *scale.Scale) (float64, error) {
+	if m.R0 <= 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrInvalidResistance)
+	}
+
+	t0, err := toKelvin(m.T0)
+	if err != nil {
+		return 0, err
+	}
+
+	t, err := toKelvin(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return resistance(m.R0 * math.Exp(m.Beta*(1/t-1/t0)))
+}
+
+// resistance returns r, or an error if r is not a positive, finite resistance, such as at
+// absolute zero.
+func resistance(r float64) (float64, error) {
+	if r <= 0 || math.IsInf(r, 0) || math.IsNaN(r) {
+		return 0, fmt.Errorf("tempconv: %w", ErrOutOfRange)
+	}
+
+	return r, nil
+}
+
+// Point is a calibration point pairing a measured resistance in ohms with a reference temperature.
+type Point struct {
+	Resistance float64
+	Temp       *scale.Scale
+}
+
+// Fit returns the Steinhart–Hart coefficients best fitting the calibration points.
+// Three points give an exact fit, more points a least-squares fit.
+func Fit(points []Point) (m SteinhartHart, err error) {
+	if len(points) < 3 {
+		return m, fmt.Errorf("tempconv: %w", ErrTooFewPoints)
+	}
+
+	// Normal equations for 1/T = A + B x + C x³ where x = ln(R)
+	a := make([][]float64, 3)
+	for i := range a {
+		a[i] = make([]float64, 4)
+	}
+
+	for _, p := range points {
+		if p.Resistance <= 0 {
+			return m, fmt.Errorf("tempconv: %w", ErrInvalidResistance)
+		}
+
+		t, err := toKelvin(p.Temp)
+		if err != nil {
+			return m, err
+		}
+
+		x := math.Log(p.Resistance)
+		row := [3]float64{1, x, x * x * x}
+		for i := range row {
+			for j := range row {
+				a[i][j] += row[i] * row[j]
+			}
+			a[i][3] += row[i] / t
+		}
+	}
+
+	coef, ok := linalg.Solve(a)
+	if !ok {
+		return m, fmt.Errorf("tempconv: %w", ErrSingular)
+	}
+
+	return SteinhartHart{A: coef[0], B: coef[1], C: coef[2]}, nil
+}
+
+func toKelvin(s *scale.Scale) (float64, error) {
+	k := scale.NewKelvin()
+	if err := convert.Convert(s, k); err != nil {
+		return 0, err
+	}
+
+	return k.Temp(), nil
+}
+
+func fromKelvin(t float64, s *scale.Scale) error {
+	k := scale.NewKelvin()
+	if err := k.SetTemp(t); err != nil {
+		return err
+	}
+
+	return convert.Convert(k, s)
+}
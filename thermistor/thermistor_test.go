@@ -0,0 +1,174 @@
+package thermistor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+// Coefficients of a 10 kΩ NTC thermistor
+var ntc = SteinhartHart{A: 1.009249522e-03, B: 2.378405444e-04, C: 2.019202697e-07}
+
+func TestSteinhartHart(t *testing.T) {
+	cases := []struct {
+		resistance float64
+		want       float64
+	}{
+		{10000, 24.681292779992702},
+		{3000, 58.29206087159366},
+		{1000, 94.66604597580681},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g Ω", c.resistance), func(t *testing.T) {
+			s := scale.NewCelsius()
+			err := ntc.Temperature(c.resistance, s)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(s.Temp()-c.want) > 1e-9 {
+				t.Errorf("got %v want %v", s.Temp(), c.want)
+			}
+
+			r, err := ntc.Resistance(s)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(r-c.resistance) > 1e-6*c.resistance {
+				t.Errorf("got %v want %v", r, c.resistance)
+			}
+		})
+	}
+}
+
+func TestBeta(t *testing.T) {
+	t0 := scale.NewCelsius()
+	err := t0.SetTemp(25)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	m := Beta{R0: 10000, T0: t0, Beta: 3950}
+	cases := []struct {
+		resistance float64
+		want       float64
+	}{
+		{10000, 25},
+		{32000, 0.936367796566401},
+		{3600, 49.91309829333807},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%g Ω", c.resistance), func(t *testing.T) {
+			s := scale.NewCelsius()
+			err := m.Temperature(c.resistance, s)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(s.Temp()-c.want) > 1e-9 {
+				t.Errorf("got %v want %v", s.Temp(), c.want)
+			}
+
+			r, err := m.Resistance(s)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			if math.Abs(r-c.resistance) > 1e-6*c.resistance {
+				t.Errorf("got %v want %v", r, c.resistance)
+			}
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	var points []Point
+	for _, temp := range []float64{0, 25, 50, 100} {
+		s := scale.NewCelsius()
+		err := s.SetTemp(temp)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+
+		r, err := ntc.Resistance(s)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		points = append(points, Point{Resistance: r, Temp: s})
+	}
+
+	for _, n := range []int{3, 4} {
+		t.Run(fmt.Sprintf("%d points", n), func(t *testing.T) {
+			m, err := Fit(points[:n])
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			for _, p := range points {
+				s := scale.NewCelsius()
+				m.Temperature(p.Resistance, s)
+				if math.Abs(s.Temp()-p.Temp.Temp()) > 1e-6 {
+					t.Errorf("got %v want %v", s.Temp(), p.Temp.Temp())
+				}
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	t0 := scale.NewCelsius()
+	err := t0.SetTemp(25)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"zero resistance", ntc.Temperature(0, scale.NewCelsius()), ErrInvalidResistance},
+		{"negative resistance", Beta{R0: 10000, T0: t0, Beta: 3950}.Temperature(-1, scale.NewCelsius()), ErrInvalidResistance},
+		{"below absolute zero", ntc.Temperature(1e-300, scale.NewCelsius()), scale.ErrAbsoluteZero},
+		{"zero model", func() error { _, err := SteinhartHart{}.Resistance(t0); return err }(), ErrInvalidModel},
+		{"steinhart-hart absolute zero", func() error { _, err := ntc.Resistance(scale.NewKelvin()); return err }(), ErrOutOfRange},
+		{"steinhart-hart negative discriminant", func() error {
+			_, err := SteinhartHart{A: 1e-3, B: -3e-4, C: 2e-7}.Resistance(t0)
+			return err
+		}(), ErrOutOfRange},
+		{"steinhart-hart without c", func() error {
+			_, err := SteinhartHart{A: 1e-3, B: 2.5e-4}.Resistance(scale.NewKelvin())
+			return err
+		}(), ErrOutOfRange},
+		{"beta absolute zero", func() error {
+			_, err := Beta{R0: 10000, T0: t0, Beta: 3950}.Resistance(scale.NewKelvin())
+			return err
+		}(), ErrOutOfRange},
+		{"beta reference at absolute zero", func() error {
+			_, err := Beta{R0: 10000, T0: scale.NewKelvin(), Beta: 3950}.Resistance(t0)
+			return err
+		}(), ErrOutOfRange},
+		{"too few points", func() error {
+			_, err := Fit([]Point{{10000, t0}, {32650, scale.NewCelsius()}})
+			return err
+		}(), ErrTooFewPoints},
+		{"singular points", func() error {
+			_, err := Fit([]Point{{10000, t0}, {10000, t0}, {10000, t0}})
+			return err
+		}(), ErrSingular},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.want) {
+				t.Errorf("got %v want %v", c.err, c.want)
+			}
+		})
+	}
+}
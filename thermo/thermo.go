@@ -0,0 +1,181 @@
+package thermo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+// GasConstant is the molar gas constant in J/(mol K).
+const GasConstant = 8.314462618
+
+var (
+	ErrNotPositive     = errors.New("pressure, volume and amount must be positive")
+	ErrZeroTemperature = errors.New("temperature must be above absolute zero")
+)
+
+// Pressure returns the pressure in Pa of n mol of an ideal gas in volume v in m³ at the temperature of t.
+func Pressure(v, n float64, t *scale.Scale) (float64, error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkPositive(v, n); err != nil {
+		return 0, err
+	}
+
+	return n * GasConstant * k / v, nil
+}
+
+// Volume returns the volume in m³ of n mol of an ideal gas at pressure p in Pa and the temperature of t.
+func Volume(p, n float64, t *scale.Scale) (float64, error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkPositive(p, n); err != nil {
+		return 0, err
+	}
+
+	return n * GasConstant * k / p, nil
+}
+
+// Amount returns the amount in mol of an ideal gas at pressure p in Pa in volume v in m³ at the temperature of t.
+func Amount(p, v float64, t *scale.Scale) (float64, error) {
+	k, err := kelvin(t)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkPositive(p, v); err != nil {
+		return 0, err
+	}
+
+	return p * v / (GasConstant * k), nil
+}
+
+// Temperature sets out to the temperature of n mol of an ideal gas at pressure p in Pa in volume v in m³.
+func Temperature(p, v, n float64, out *scale.Scale) error {
+	if err := checkPositive(p, v, n); err != nil {
+		return err
+	}
+
+	k := scale.NewKelvin()
+	if err := k.SetTemp(p * v / (n * GasConstant)); err != nil {
+		return err
+	}
+
+	return convert.Convert(k, out)
+}
+
+// Ratio returns the ratio of the absolute temperatures t2/t1.
+func Ratio(t1, t2 *scale.Scale) (float64, error) {
+	k1, err := kelvin(t1)
+	if err != nil {
+		return 0, err
+	}
+
+	k2, err := kelvin(t2)
+	if err != nil {
+		return 0, err
+	}
+
+	return k2 / k1, nil
+}
+
+// CharlesVolume returns the volume of a gas at constant pressure after changing from the temperature of t1
+// to the temperature of t2, given its volume v1 at t1, according to Charles's law.
+func CharlesVolume(v1 float64, t1, t2 *scale.Scale) (float64, error) {
+	r, err := Ratio(t1, t2)
+	if err != nil {
+		return 0, err
+	}
+
+	return v1 * r, nil
+}
+
+// GayLussacPressure returns the pressure of a gas at constant volume after changing from the temperature of t1
+// to the temperature of t2, given its pressure p1 at t1, according to Gay-Lussac's law.
+func GayLussacPressure(p1 float64, t1, t2 *scale.Scale) (float64, error) {
+	r, err := Ratio(t1, t2)
+	if err != nil {
+		return 0, err
+	}
+
+	return p1 * r, nil
+}
+
+// LinearExpansion returns the length of a body with length l0 at the temperature of t1 and linear expansion
+// coefficient alpha in 1/K after changing to the temperature of t2.
+func LinearExpansion(l0, alpha float64, t1, t2 *scale.Scale) (float64, error) {
+	d, err := difference(t1, t2)
+	if err != nil {
+		return 0, err
+	}
+
+	return l0 * (1 + alpha*d), nil
+}
+
+// VolumetricExpansion returns the volume of a body with volume v0 at the temperature of t1 and volumetric
+// expansion coefficient beta in 1/K after changing to the temperature of t2.
+func VolumetricExpansion(v0, beta float64, t1, t2 *scale.Scale) (float64, error) {
+	d, err := difference(t1, t2)
+	if err != nil {
+		return 0, err
+	}
+
+	return v0 * (1 + beta*d), nil
+}
+
+// difference returns the temperature difference t2 - t1 in kelvin.
+func difference(t1, t2 *scale.Scale) (float64, error) {
+	k1, err := toKelvin(t1)
+	if err != nil {
+		return 0, err
+	}
+
+	k2, err := toKelvin(t2)
+	if err != nil {
+		return 0, err
+	}
+
+	return k2 - k1, nil
+}
+
+func checkPositive(values ...float64) error {
+	for _, v := range values {
+		if v <= 0 {
+			return fmt.Errorf("tempconv: %w", ErrNotPositive)
+		}
+	}
+
+	return nil
+}
+
+// kelvin returns the temperature of s in kelvin, which must be above absolute zero.
+func kelvin(s *scale.Scale) (float64, error) {
+	k, err := toKelvin(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if k == 0 {
+		return 0, fmt.Errorf("tempconv: %w", ErrZeroTemperature)
+	}
+
+	return k, nil
+}
+
+// toKelvin returns the temperature of s in kelvin, converted with convert.Convert.
+func toKelvin(s *scale.Scale) (float64, error) {
+	k := scale.NewKelvin()
+	if err := convert.Convert(s, k); err != nil {
+		return 0, err
+	}
+
+	return k.Temp(), nil
+}
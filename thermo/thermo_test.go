@@ -0,0 +1,151 @@
+package thermo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+func assertRelative(t *testing.T, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want)/math.Abs(want) > 1e-9 {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestIdealGas(t *testing.T) {
+	// One mole at standard temperature and pressure
+	p, v, n := 100000.0, 0.02271095464, 1.0
+
+	t.Run("pressure", func(t *testing.T) {
+		got, err := Pressure(v, n, scale.NewCelsius())
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, p)
+	})
+
+	t.Run("volume", func(t *testing.T) {
+		got, err := Volume(p, n, scale.NewCelsius())
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, v)
+	})
+
+	t.Run("amount", func(t *testing.T) {
+		got, err := Amount(p, v, scale.NewCelsius())
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, n)
+	})
+
+	t.Run("temperature", func(t *testing.T) {
+		out := scale.NewKelvin()
+		err := Temperature(p, v, n, out)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, out.Temp(), 273.15)
+	})
+}
+
+func TestGasLaws(t *testing.T) {
+	f := scale.NewFahrenheit()
+	err := f.SetTemp(212)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	hot := scale.NewCelsius()
+	err = hot.SetTemp(273.15)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	t.Run("ratio", func(t *testing.T) {
+		got, err := Ratio(scale.NewCelsius(), f)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, 373.15/273.15)
+	})
+
+	t.Run("charles", func(t *testing.T) {
+		got, err := CharlesVolume(2, scale.NewCelsius(), hot)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, 4)
+	})
+
+	t.Run("gay-lussac", func(t *testing.T) {
+		got, err := GayLussacPressure(100000, hot, scale.NewCelsius())
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, 50000)
+	})
+}
+
+func TestExpansion(t *testing.T) {
+	f := scale.NewFahrenheit()
+	err := f.SetTemp(212)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	boiling := scale.NewCelsius()
+	err = boiling.SetTemp(100)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	t.Run("linear", func(t *testing.T) {
+		// Steel rod heated from 0 °C to 100 °C
+		got, err := LinearExpansion(1, 12e-6, scale.NewCelsius(), f)
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, 1.0012)
+	})
+
+	t.Run("volumetric", func(t *testing.T) {
+		got, err := VolumetricExpansion(1, 36e-6, boiling, scale.NewCelsius())
+		if err != nil {
+			t.Fatalf("got %v want nil", err)
+		}
+		assertRelative(t, got, 0.9964)
+	})
+}
+
+func TestError(t *testing.T) {
+	zero := scale.NewCelsius()
+	err := zero.SetTemp(-273.15)
+	if err != nil {
+		t.Fatalf("got %v want nil", err)
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"zero volume", func() error { _, err := Pressure(0, 1, scale.NewCelsius()); return err }(), ErrNotPositive},
+		{"negative amount", func() error { _, err := Volume(100000, -1, scale.NewCelsius()); return err }(), ErrNotPositive},
+		{"zero pressure", Temperature(0, 1, 1, scale.NewKelvin()), ErrNotPositive},
+		{"absolute zero", func() error { _, err := Amount(100000, 1, zero); return err }(), ErrZeroTemperature},
+		{"absolute zero ratio", func() error { _, err := Ratio(zero, scale.NewCelsius()); return err }(), ErrZeroTemperature},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if !errors.Is(c.err, c.want) {
+				t.Errorf("got %v want %v", c.err, c.want)
+			}
+		})
+	}
+}
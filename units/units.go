@@ -0,0 +1,148 @@
+// Package units maps temperature scales to and from the unit codes of standard code systems, so
+// data from those ecosystems can be converted with the convert package.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+// Code systems.
+const (
+	UCUM   = "ucum"   // Unified Code for Units of Measure, case sensitive codes
+	UNECE  = "unece"  // UN/ECE Recommendation No. 20 common codes
+	QUDT   = "qudt"   // QUDT unit vocabulary, as local names, unit: CURIEs or IRIs
+	BACnet = "bacnet" // BACnet engineering units, as enumeration values or names
+)
+
+// QUDTNamespace is the namespace of the QUDT unit vocabulary.
+const QUDTNamespace = "http://qudt.org/vocab/unit/"
+
+var (
+	ErrUnknownSystem = errors.New("unknown unit code system")
+	ErrUnknownCode   = errors.New("unknown unit code")
+	ErrNoCode        = errors.New("scale has no unit code in code system")
+)
+
+// codes holds the codes of a scale type in each code system. An empty code means the code system
+// has no unit for the scale.
+type codes struct {
+	scale      int
+	ucum       string
+	unece      string
+	qudt       string
+	bacnet     string
+	bacnetName string
+}
+
+var table = []codes{
+	{scale.KELVIN, "K", "KEL", "K", "63", "degrees-kelvin"},
+	{scale.CELSIUS, "Cel", "CEL", "DEG_C", "62", "degrees-celsius"},
+	{scale.FAHRENHEIT, "[degF]", "FAH", "DEG_F", "64", "degrees-fahrenheit"},
+	{scale.RANKINE, "[degR]", "A48", "DEG_R", "", ""},
+	{scale.REAUMUR, "[degRe]", "", "", "", ""},
+}
+
+// Systems returns the names of the supported code systems.
+func Systems() []string {
+	systems := []string{UCUM, UNECE, QUDT, BACnet}
+	sort.Strings(systems)
+	return systems
+}
+
+// Lookup returns a new scale for code in system.
+func Lookup(system, code string) (*scale.Scale, error) {
+	code = strings.TrimSpace(code)
+
+	for _, c := range table {
+		var match bool
+		switch system {
+		case UCUM:
+			match = c.ucum != "" && code == c.ucum
+		case UNECE:
+			match = c.unece != "" && strings.EqualFold(code, c.unece)
+		case QUDT:
+			local := strings.TrimPrefix(strings.TrimPrefix(code, QUDTNamespace), "unit:")
+			match = c.qudt != "" && local == c.qudt
+		case BACnet:
+			match = c.bacnet != "" && (code == c.bacnet || strings.EqualFold(code, c.bacnetName))
+		default:
+			return nil, fmt.Errorf("tempconv: %w: %s", ErrUnknownSystem, system)
+		}
+
+		if match {
+			return newScale(c.scale), nil
+		}
+	}
+
+	return nil, fmt.Errorf("tempconv: %w: %s:%s", ErrUnknownCode, system, code)
+}
+
+// Parse returns a new scale for a code qualified with its code system as system:code, such as
+// ucum:Cel, unece:FAH, qudt:unit:DEG_C or bacnet:62. The code system is case insensitive.
+func Parse(qualified string) (*scale.Scale, error) {
+	system, code, found := strings.Cut(qualified, ":")
+	if !found {
+		return nil, fmt.Errorf("tempconv: %w: %s", ErrUnknownCode, qualified)
+	}
+
+	return Lookup(strings.ToLower(system), code)
+}
+
+// Code returns the code of s in system. QUDT codes are returned as IRIs and BACnet codes as
+// enumeration values.
+func Code(system string, s *scale.Scale) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("tempconv: %w: nil scale", scale.ErrUnknownScale)
+	}
+
+	for _, c := range table {
+		if c.scale != s.Type {
+			continue
+		}
+
+		var code string
+		switch system {
+		case UCUM:
+			code = c.ucum
+		case UNECE:
+			code = c.unece
+		case QUDT:
+			if c.qudt != "" {
+				code = QUDTNamespace + c.qudt
+			}
+		case BACnet:
+			code = c.bacnet
+		default:
+			return "", fmt.Errorf("tempconv: %w: %s", ErrUnknownSystem, system)
+		}
+
+		if code != "" {
+			return code, nil
+		}
+	}
+
+	if !contains(Systems(), system) {
+		return "", fmt.Errorf("tempconv: %w: %s", ErrUnknownSystem, system)
+	}
+
+	return "", fmt.Errorf("tempconv: %w: %s, %s", ErrNoCode, s.Name, system)
+}
+
+func newScale(t int) *scale.Scale {
+	return scale.All()[t]
+}
+
+func contains(slice []string, s string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
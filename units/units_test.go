@@ -0,0 +1,212 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		system string
+		code   string
+		want   int
+	}{
+		{UCUM, "K", scale.KELVIN},
+		{UCUM, "Cel", scale.CELSIUS},
+		{UCUM, "[degF]", scale.FAHRENHEIT},
+		{UCUM, "[degR]", scale.RANKINE},
+		{UCUM, "[degRe]", scale.REAUMUR},
+		{UNECE, "KEL", scale.KELVIN},
+		{UNECE, "CEL", scale.CELSIUS},
+		{UNECE, "cel", scale.CELSIUS},
+		{UNECE, "FAH", scale.FAHRENHEIT},
+		{UNECE, "A48", scale.RANKINE},
+		{QUDT, "K", scale.KELVIN},
+		{QUDT, "DEG_C", scale.CELSIUS},
+		{QUDT, "unit:DEG_F", scale.FAHRENHEIT},
+		{QUDT, "http://qudt.org/vocab/unit/DEG_R", scale.RANKINE},
+		{BACnet, "62", scale.CELSIUS},
+		{BACnet, "63", scale.KELVIN},
+		{BACnet, "64", scale.FAHRENHEIT},
+		{BACnet, "degrees-celsius", scale.CELSIUS},
+	}
+
+	for _, c := range cases {
+		t.Run(c.system+":"+c.code, func(t *testing.T) {
+			s, err := Lookup(c.system, c.code)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if s.Type != c.want {
+				t.Errorf("got %v want %v", s.Type, c.want)
+			}
+		})
+	}
+}
+
+func TestLookupError(t *testing.T) {
+	cases := []struct {
+		system string
+		code   string
+		err    error
+	}{
+		{UCUM, "cel", ErrUnknownCode},
+		{UCUM, "degF", ErrUnknownCode},
+		{UCUM, "", ErrUnknownCode},
+		{UNECE, "KELVIN", ErrUnknownCode},
+		{QUDT, "unit:DEG_X", ErrUnknownCode},
+		{BACnet, "65", ErrUnknownCode},
+		{"si", "K", ErrUnknownSystem},
+	}
+
+	for _, c := range cases {
+		t.Run(c.system+":"+c.code, func(t *testing.T) {
+			_, err := Lookup(c.system, c.code)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		qualified string
+		want      int
+	}{
+		{"ucum:Cel", scale.CELSIUS},
+		{"UCUM:Cel", scale.CELSIUS},
+		{"unece:FAH", scale.FAHRENHEIT},
+		{"qudt:unit:DEG_C", scale.CELSIUS},
+		{"qudt:http://qudt.org/vocab/unit/K", scale.KELVIN},
+		{"bacnet:62", scale.CELSIUS},
+	}
+
+	for _, c := range cases {
+		t.Run(c.qualified, func(t *testing.T) {
+			s, err := Parse(c.qualified)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if s.Type != c.want {
+				t.Errorf("got %v want %v", s.Type, c.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		qualified string
+		err       error
+	}{
+		{"Cel", ErrUnknownCode},
+		{"ucum:", ErrUnknownCode},
+		{"si:K", ErrUnknownSystem},
+	}
+
+	for _, c := range cases {
+		t.Run(c.qualified, func(t *testing.T) {
+			_, err := Parse(c.qualified)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	cases := []struct {
+		system string
+		scale  *scale.Scale
+		want   string
+	}{
+		{UCUM, scale.NewKelvin(), "K"},
+		{UCUM, scale.NewCelsius(), "Cel"},
+		{UCUM, scale.NewReaumur(), "[degRe]"},
+		{UNECE, scale.NewRankine(), "A48"},
+		{QUDT, scale.NewCelsius(), "http://qudt.org/vocab/unit/DEG_C"},
+		{BACnet, scale.NewFahrenheit(), "64"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.system+":"+c.scale.Name, func(t *testing.T) {
+			got, err := Code(c.system, c.scale)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if got != c.want {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestCodeError(t *testing.T) {
+	cases := []struct {
+		system string
+		scale  *scale.Scale
+		err    error
+	}{
+		{UCUM, scale.NewDelisle(), ErrNoCode},
+		{UNECE, scale.NewReaumur(), ErrNoCode},
+		{BACnet, scale.NewRankine(), ErrNoCode},
+		{"si", scale.NewKelvin(), ErrUnknownSystem},
+		{"si", scale.NewDelisle(), ErrUnknownSystem},
+		{UCUM, nil, scale.ErrUnknownScale},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s:%v", c.system, c.scale), func(t *testing.T) {
+			_, err := Code(c.system, c.scale)
+			if !errors.Is(err, c.err) {
+				t.Errorf("got %v want %v", err, c.err)
+			}
+		})
+	}
+}
+
+// TestRoundTrip checks that every code maps back to the scale it came from.
+func TestRoundTrip(t *testing.T) {
+	for _, system := range Systems() {
+		for _, s := range scale.All() {
+			code, err := Code(system, s)
+			if errors.Is(err, ErrNoCode) {
+				continue
+			} else if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			got, err := Lookup(system, code)
+			if err != nil {
+				t.Fatalf("got %v want %v", err, nil)
+			}
+
+			if got.Type != s.Type {
+				t.Errorf("%s:%s got %v want %v", system, code, got.Type, s.Type)
+			}
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	input, _ := Parse("ucum:Cel")
+	output, _ := Parse("unece:FAH")
+
+	input.SetTemp(100)
+	err := convert.Convert(input, output)
+	if err != nil {
+		t.Fatalf("got %v want %v", err, nil)
+	}
+
+	if output.Temp() != 212 {
+		t.Errorf("got %v want %v", output.Temp(), 212)
+	}
+}
@@ -0,0 +1,128 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/solbero/tempconv/convert"
+	"github.com/solbero/tempconv/scale"
+)
+
+var (
+	ErrInvalidHumidity  = errors.New("relative humidity must be between 0 and 100 percent")
+	ErrInvalidWindSpeed = errors.New("wind speed must not be negative")
+	ErrOutOfRange       = errors.New("wind chill is only defined at or below 10 °C and wind speeds above 4.8 km/h")
+)
+
+// HeatIndex sets out to the NWS heat index for the air temperature t and relative humidity rh in percent.
+// The Rothfusz regression is used with the NWS adjustments, falling back to Steadman's simple formula
+// when the heat index is below 80 °F.
+func HeatIndex(t *scale.Scale, rh float64, out *scale.Scale) error {
+	if err := checkHumidity(rh); err != nil {
+		return err
+	}
+
+	f, err := temp(t, scale.NewFahrenheit())
+	if err != nil {
+		return err
+	}
+
+	hi := 0.5 * (f + 61 + (f-68)*1.2 + rh*0.094)
+	if (hi+f)/2 >= 80 {
+		hi = -42.379 + 2.04901523*f + 10.14333127*rh - 0.22475541*f*rh -
+			0.00683783*f*f - 0.05481717*rh*rh + 0.00122874*f*f*rh +
+			0.00085282*f*rh*rh - 0.00000199*f*f*rh*rh
+
+		if rh < 13 && f >= 80 && f <= 112 {
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(f-95))/17)
+		} else if rh > 85 && f >= 80 && f <= 87 {
+			hi += (rh - 85) / 10 * (87 - f) / 5
+		}
+	}
+
+	return set(hi, scale.NewFahrenheit(), out)
+}
+
+// WindChill sets out to the NWS/Environment Canada wind chill for the air temperature t and wind speed in km/h.
+// It returns an error outside the range the index is defined for.
+func WindChill(t *scale.Scale, wind float64, out *scale.Scale) error {
+	if wind < 0 {
+		return fmt.Errorf("tempconv: %w", ErrInvalidWindSpeed)
+	}
+
+	c, err := temp(t, scale.NewCelsius())
+	if err != nil {
+		return err
+	}
+
+	if c > 10 || wind <= 4.8 {
+		return fmt.Errorf("tempconv: %w", ErrOutOfRange)
+	}
+
+	v := math.Pow(wind, 0.16)
+	return set(13.12+0.6215*c-11.37*v+0.3965*c*v, scale.NewCelsius(), out)
+}
+
+// DewPoint sets out to the dew point for the air temperature t and relative humidity rh in percent,
+// using the Magnus formula.
+func DewPoint(t *scale.Scale, rh float64, out *scale.Scale) error {
+	if err := checkHumidity(rh); err != nil {
+		return err
+	}
+
+	c, err := temp(t, scale.NewCelsius())
+	if err != nil {
+		return err
+	}
+
+	return set(dewPoint(c, rh), scale.NewCelsius(), out)
+}
+
+// Humidex sets out to the Environment Canada humidex for the air temperature t and relative humidity rh in percent.
+func Humidex(t *scale.Scale, rh float64, out *scale.Scale) error {
+	if err := checkHumidity(rh); err != nil {
+		return err
+	}
+
+	c, err := temp(t, scale.NewCelsius())
+	if err != nil {
+		return err
+	}
+
+	td := dewPoint(c, rh)
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/(td+273.15)))
+	return set(c+0.5555*(e-10), scale.NewCelsius(), out)
+}
+
+func dewPoint(c, rh float64) float64 {
+	b, l := 17.62, 243.12
+	g := math.Log(rh/100) + b*c/(l+c)
+	return l * g / (b - g)
+}
+
+func checkHumidity(rh float64) error {
+	if rh <= 0 || rh > 100 {
+		return fmt.Errorf("tempconv: %w", ErrInvalidHumidity)
+	}
+
+	return nil
+}
+
+// temp returns the temperature of s in the scale of target.
+func temp(s, target *scale.Scale) (float64, error) {
+	if err := convert.Convert(s, target); err != nil {
+		return 0, err
+	}
+
+	return target.Temp(), nil
+}
+
+// set sets out to the temperature t given in the scale of from.
+func set(t float64, from, out *scale.Scale) error {
+	if err := from.SetTemp(t); err != nil {
+		return err
+	}
+
+	return convert.Convert(from, out)
+}
@@ -0,0 +1,83 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/solbero/tempconv/scale"
+)
+
+type index func(t *scale.Scale, v float64, out *scale.Scale) error
+
+func TestIndex(t *testing.T) {
+	cases := []struct {
+		name   string
+		index  index
+		input  *scale.Scale
+		temp   float64
+		value  float64
+		output *scale.Scale
+		want   float64
+	}{
+		{"heat index", HeatIndex, scale.NewFahrenheit(), 90, 60, scale.NewFahrenheit(), 99.6777179},
+		{"heat index simple", HeatIndex, scale.NewFahrenheit(), 70, 50, scale.NewFahrenheit(), 69.05},
+		{"heat index celsius", HeatIndex, scale.NewCelsius(), 32.2222222, 60, scale.NewCelsius(), 37.5987322},
+		{"wind chill", WindChill, scale.NewCelsius(), -10, 30, scale.NewCelsius(), -19.5204980},
+		{"dew point", DewPoint, scale.NewCelsius(), 20, 50, scale.NewCelsius(), 9.2551746},
+		{"dew point kelvin", DewPoint, scale.NewKelvin(), 293.15, 50, scale.NewKelvin(), 282.4051746},
+		{"humidex", Humidex, scale.NewCelsius(), 30, 70, scale.NewCelsius(), 41.2019660},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s %g %v", c.name, c.temp, c.input.Name), func(t *testing.T) {
+			err := c.input.SetTemp(c.temp)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			err = c.index(c.input, c.value, c.output)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			got := c.output.Temp()
+			if math.Abs(got-c.want) > 1e-6 {
+				t.Errorf("got %v want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestIndexError(t *testing.T) {
+	cases := []struct {
+		name  string
+		index index
+		input *scale.Scale
+		temp  float64
+		value float64
+		want  error
+	}{
+		{"heat index humidity", HeatIndex, scale.NewCelsius(), 30, 101, ErrInvalidHumidity},
+		{"dew point humidity", DewPoint, scale.NewCelsius(), 30, 0, ErrInvalidHumidity},
+		{"humidex humidity", Humidex, scale.NewCelsius(), 30, -5, ErrInvalidHumidity},
+		{"wind chill wind speed", WindChill, scale.NewCelsius(), 0, -1, ErrInvalidWindSpeed},
+		{"wind chill warm", WindChill, scale.NewCelsius(), 20, 30, ErrOutOfRange},
+		{"wind chill calm", WindChill, scale.NewCelsius(), 0, 2, ErrOutOfRange},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.input.SetTemp(c.temp)
+			if err != nil {
+				t.Fatalf("got %v want nil", err)
+			}
+
+			err = c.index(c.input, c.value, scale.NewCelsius())
+			if !errors.Is(err, c.want) {
+				t.Errorf("got %v want %v", err, c.want)
+			}
+		})
+	}
+}